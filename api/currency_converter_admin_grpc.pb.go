@@ -0,0 +1,334 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package currencyconverter
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// CurrencyConverterAdminClient is the client API for CurrencyConverterAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CurrencyConverterAdminClient interface {
+	// RefreshRates runs the update rates workflow once, outside of the cron schedule
+	RefreshRates(ctx context.Context, in *RefreshRatesRequest, opts ...grpc.CallOption) (*RefreshRatesResponse, error)
+	// BackfillRates fills the days of the fiat rates history that have no rates with the historical rates of the providers.
+	// It returns once the backfill workflow started, the workflow might run for a while as the requests are paced.
+	BackfillRates(ctx context.Context, in *BackfillRatesRequest, opts ...grpc.CallOption) (*BackfillRatesResponse, error)
+	// GetSchedule returns the state of the schedule refreshing the rates of an asset class
+	GetSchedule(ctx context.Context, in *GetScheduleRequest, opts ...grpc.CallOption) (*Schedule, error)
+	// PauseSchedule stops the scheduled runs until the schedule is resumed
+	PauseSchedule(ctx context.Context, in *ScheduleSignalRequest, opts ...grpc.CallOption) (*ScheduleSignalResponse, error)
+	// ResumeSchedule restarts the scheduled runs of a paused schedule
+	ResumeSchedule(ctx context.Context, in *ScheduleSignalRequest, opts ...grpc.CallOption) (*ScheduleSignalResponse, error)
+	// TriggerSchedule makes the schedule run right away, it's a run of the schedule even when it's paused
+	TriggerSchedule(ctx context.Context, in *ScheduleSignalRequest, opts ...grpc.CallOption) (*ScheduleSignalResponse, error)
+	// UpdateSchedule replaces the cron spec of the schedule
+	UpdateSchedule(ctx context.Context, in *UpdateScheduleRequest, opts ...grpc.CallOption) (*ScheduleSignalResponse, error)
+}
+
+type currencyConverterAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCurrencyConverterAdminClient(cc grpc.ClientConnInterface) CurrencyConverterAdminClient {
+	return &currencyConverterAdminClient{cc}
+}
+
+func (c *currencyConverterAdminClient) RefreshRates(ctx context.Context, in *RefreshRatesRequest, opts ...grpc.CallOption) (*RefreshRatesResponse, error) {
+	out := new(RefreshRatesResponse)
+	err := c.cc.Invoke(ctx, "/currencyconverter.CurrencyConverterAdmin/RefreshRates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyConverterAdminClient) BackfillRates(ctx context.Context, in *BackfillRatesRequest, opts ...grpc.CallOption) (*BackfillRatesResponse, error) {
+	out := new(BackfillRatesResponse)
+	err := c.cc.Invoke(ctx, "/currencyconverter.CurrencyConverterAdmin/BackfillRates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyConverterAdminClient) GetSchedule(ctx context.Context, in *GetScheduleRequest, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, "/currencyconverter.CurrencyConverterAdmin/GetSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyConverterAdminClient) PauseSchedule(ctx context.Context, in *ScheduleSignalRequest, opts ...grpc.CallOption) (*ScheduleSignalResponse, error) {
+	out := new(ScheduleSignalResponse)
+	err := c.cc.Invoke(ctx, "/currencyconverter.CurrencyConverterAdmin/PauseSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyConverterAdminClient) ResumeSchedule(ctx context.Context, in *ScheduleSignalRequest, opts ...grpc.CallOption) (*ScheduleSignalResponse, error) {
+	out := new(ScheduleSignalResponse)
+	err := c.cc.Invoke(ctx, "/currencyconverter.CurrencyConverterAdmin/ResumeSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyConverterAdminClient) TriggerSchedule(ctx context.Context, in *ScheduleSignalRequest, opts ...grpc.CallOption) (*ScheduleSignalResponse, error) {
+	out := new(ScheduleSignalResponse)
+	err := c.cc.Invoke(ctx, "/currencyconverter.CurrencyConverterAdmin/TriggerSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyConverterAdminClient) UpdateSchedule(ctx context.Context, in *UpdateScheduleRequest, opts ...grpc.CallOption) (*ScheduleSignalResponse, error) {
+	out := new(ScheduleSignalResponse)
+	err := c.cc.Invoke(ctx, "/currencyconverter.CurrencyConverterAdmin/UpdateSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CurrencyConverterAdminServer is the server API for CurrencyConverterAdmin service.
+// All implementations must embed UnimplementedCurrencyConverterAdminServer
+// for forward compatibility
+type CurrencyConverterAdminServer interface {
+	// RefreshRates runs the update rates workflow once, outside of the cron schedule
+	RefreshRates(context.Context, *RefreshRatesRequest) (*RefreshRatesResponse, error)
+	// BackfillRates fills the days of the fiat rates history that have no rates with the historical rates of the providers.
+	// It returns once the backfill workflow started, the workflow might run for a while as the requests are paced.
+	BackfillRates(context.Context, *BackfillRatesRequest) (*BackfillRatesResponse, error)
+	// GetSchedule returns the state of the schedule refreshing the rates of an asset class
+	GetSchedule(context.Context, *GetScheduleRequest) (*Schedule, error)
+	// PauseSchedule stops the scheduled runs until the schedule is resumed
+	PauseSchedule(context.Context, *ScheduleSignalRequest) (*ScheduleSignalResponse, error)
+	// ResumeSchedule restarts the scheduled runs of a paused schedule
+	ResumeSchedule(context.Context, *ScheduleSignalRequest) (*ScheduleSignalResponse, error)
+	// TriggerSchedule makes the schedule run right away, it's a run of the schedule even when it's paused
+	TriggerSchedule(context.Context, *ScheduleSignalRequest) (*ScheduleSignalResponse, error)
+	// UpdateSchedule replaces the cron spec of the schedule
+	UpdateSchedule(context.Context, *UpdateScheduleRequest) (*ScheduleSignalResponse, error)
+	mustEmbedUnimplementedCurrencyConverterAdminServer()
+}
+
+// UnimplementedCurrencyConverterAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedCurrencyConverterAdminServer struct {
+}
+
+func (UnimplementedCurrencyConverterAdminServer) RefreshRates(context.Context, *RefreshRatesRequest) (*RefreshRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshRates not implemented")
+}
+func (UnimplementedCurrencyConverterAdminServer) BackfillRates(context.Context, *BackfillRatesRequest) (*BackfillRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackfillRates not implemented")
+}
+func (UnimplementedCurrencyConverterAdminServer) GetSchedule(context.Context, *GetScheduleRequest) (*Schedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSchedule not implemented")
+}
+func (UnimplementedCurrencyConverterAdminServer) PauseSchedule(context.Context, *ScheduleSignalRequest) (*ScheduleSignalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseSchedule not implemented")
+}
+func (UnimplementedCurrencyConverterAdminServer) ResumeSchedule(context.Context, *ScheduleSignalRequest) (*ScheduleSignalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeSchedule not implemented")
+}
+func (UnimplementedCurrencyConverterAdminServer) TriggerSchedule(context.Context, *ScheduleSignalRequest) (*ScheduleSignalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerSchedule not implemented")
+}
+func (UnimplementedCurrencyConverterAdminServer) UpdateSchedule(context.Context, *UpdateScheduleRequest) (*ScheduleSignalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSchedule not implemented")
+}
+func (UnimplementedCurrencyConverterAdminServer) mustEmbedUnimplementedCurrencyConverterAdminServer() {
+}
+
+// UnsafeCurrencyConverterAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CurrencyConverterAdminServer will
+// result in compilation errors.
+type UnsafeCurrencyConverterAdminServer interface {
+	mustEmbedUnimplementedCurrencyConverterAdminServer()
+}
+
+func RegisterCurrencyConverterAdminServer(s grpc.ServiceRegistrar, srv CurrencyConverterAdminServer) {
+	s.RegisterService(&CurrencyConverterAdmin_ServiceDesc, srv)
+}
+
+func _CurrencyConverterAdmin_RefreshRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyConverterAdminServer).RefreshRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currencyconverter.CurrencyConverterAdmin/RefreshRates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyConverterAdminServer).RefreshRates(ctx, req.(*RefreshRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyConverterAdmin_BackfillRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackfillRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyConverterAdminServer).BackfillRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currencyconverter.CurrencyConverterAdmin/BackfillRates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyConverterAdminServer).BackfillRates(ctx, req.(*BackfillRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyConverterAdmin_GetSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyConverterAdminServer).GetSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currencyconverter.CurrencyConverterAdmin/GetSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyConverterAdminServer).GetSchedule(ctx, req.(*GetScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyConverterAdmin_PauseSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleSignalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyConverterAdminServer).PauseSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currencyconverter.CurrencyConverterAdmin/PauseSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyConverterAdminServer).PauseSchedule(ctx, req.(*ScheduleSignalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyConverterAdmin_ResumeSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleSignalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyConverterAdminServer).ResumeSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currencyconverter.CurrencyConverterAdmin/ResumeSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyConverterAdminServer).ResumeSchedule(ctx, req.(*ScheduleSignalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyConverterAdmin_TriggerSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleSignalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyConverterAdminServer).TriggerSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currencyconverter.CurrencyConverterAdmin/TriggerSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyConverterAdminServer).TriggerSchedule(ctx, req.(*ScheduleSignalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyConverterAdmin_UpdateSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyConverterAdminServer).UpdateSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currencyconverter.CurrencyConverterAdmin/UpdateSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyConverterAdminServer).UpdateSchedule(ctx, req.(*UpdateScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CurrencyConverterAdmin_ServiceDesc is the grpc.ServiceDesc for CurrencyConverterAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CurrencyConverterAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "currencyconverter.CurrencyConverterAdmin",
+	HandlerType: (*CurrencyConverterAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RefreshRates",
+			Handler:    _CurrencyConverterAdmin_RefreshRates_Handler,
+		},
+		{
+			MethodName: "BackfillRates",
+			Handler:    _CurrencyConverterAdmin_BackfillRates_Handler,
+		},
+		{
+			MethodName: "GetSchedule",
+			Handler:    _CurrencyConverterAdmin_GetSchedule_Handler,
+		},
+		{
+			MethodName: "PauseSchedule",
+			Handler:    _CurrencyConverterAdmin_PauseSchedule_Handler,
+		},
+		{
+			MethodName: "ResumeSchedule",
+			Handler:    _CurrencyConverterAdmin_ResumeSchedule_Handler,
+		},
+		{
+			MethodName: "TriggerSchedule",
+			Handler:    _CurrencyConverterAdmin_TriggerSchedule_Handler,
+		},
+		{
+			MethodName: "UpdateSchedule",
+			Handler:    _CurrencyConverterAdmin_UpdateSchedule_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/currency_converter_admin.proto",
+}
@@ -19,6 +19,16 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CurrencyConverterClient interface {
 	Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error)
+	BatchConvert(ctx context.Context, in *BatchConvertRequest, opts ...grpc.CallOption) (*BatchConvertResponse, error)
+	WatchRates(ctx context.Context, in *WatchRatesRequest, opts ...grpc.CallOption) (CurrencyConverter_WatchRatesClient, error)
+	ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error)
+	GetRate(ctx context.Context, in *GetRateRequest, opts ...grpc.CallOption) (*GetRateResponse, error)
+	// GetRateHistory returns the OHLC series of the cross rate between start and end
+	GetRateHistory(ctx context.Context, in *GetRateHistoryRequest, opts ...grpc.CallOption) (*GetRateHistoryResponse, error)
+	// CreateQuote locks the current cross rate for the configured time to live
+	CreateQuote(ctx context.Context, in *CreateQuoteRequest, opts ...grpc.CallOption) (*Quote, error)
+	// ConvertWithQuote converts at the rate locked by the quote, fails with FAILED_PRECONDITION once it expired
+	ConvertWithQuote(ctx context.Context, in *ConvertWithQuoteRequest, opts ...grpc.CallOption) (*ConvertResponse, error)
 }
 
 type currencyConverterClient struct {
@@ -38,11 +48,107 @@ func (c *currencyConverterClient) Convert(ctx context.Context, in *ConvertReques
 	return out, nil
 }
 
+func (c *currencyConverterClient) BatchConvert(ctx context.Context, in *BatchConvertRequest, opts ...grpc.CallOption) (*BatchConvertResponse, error) {
+	out := new(BatchConvertResponse)
+	err := c.cc.Invoke(ctx, "/currencyconverter.CurrencyConverter/BatchConvert", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyConverterClient) WatchRates(ctx context.Context, in *WatchRatesRequest, opts ...grpc.CallOption) (CurrencyConverter_WatchRatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CurrencyConverter_ServiceDesc.Streams[0], "/currencyconverter.CurrencyConverter/WatchRates", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &currencyConverterWatchRatesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CurrencyConverter_WatchRatesClient interface {
+	Recv() (*RatesSnapshot, error)
+	grpc.ClientStream
+}
+
+type currencyConverterWatchRatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *currencyConverterWatchRatesClient) Recv() (*RatesSnapshot, error) {
+	m := new(RatesSnapshot)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *currencyConverterClient) ListCurrencies(ctx context.Context, in *ListCurrenciesRequest, opts ...grpc.CallOption) (*ListCurrenciesResponse, error) {
+	out := new(ListCurrenciesResponse)
+	err := c.cc.Invoke(ctx, "/currencyconverter.CurrencyConverter/ListCurrencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyConverterClient) GetRate(ctx context.Context, in *GetRateRequest, opts ...grpc.CallOption) (*GetRateResponse, error) {
+	out := new(GetRateResponse)
+	err := c.cc.Invoke(ctx, "/currencyconverter.CurrencyConverter/GetRate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyConverterClient) GetRateHistory(ctx context.Context, in *GetRateHistoryRequest, opts ...grpc.CallOption) (*GetRateHistoryResponse, error) {
+	out := new(GetRateHistoryResponse)
+	err := c.cc.Invoke(ctx, "/currencyconverter.CurrencyConverter/GetRateHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyConverterClient) CreateQuote(ctx context.Context, in *CreateQuoteRequest, opts ...grpc.CallOption) (*Quote, error) {
+	out := new(Quote)
+	err := c.cc.Invoke(ctx, "/currencyconverter.CurrencyConverter/CreateQuote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyConverterClient) ConvertWithQuote(ctx context.Context, in *ConvertWithQuoteRequest, opts ...grpc.CallOption) (*ConvertResponse, error) {
+	out := new(ConvertResponse)
+	err := c.cc.Invoke(ctx, "/currencyconverter.CurrencyConverter/ConvertWithQuote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CurrencyConverterServer is the server API for CurrencyConverter service.
 // All implementations must embed UnimplementedCurrencyConverterServer
 // for forward compatibility
 type CurrencyConverterServer interface {
 	Convert(context.Context, *ConvertRequest) (*ConvertResponse, error)
+	BatchConvert(context.Context, *BatchConvertRequest) (*BatchConvertResponse, error)
+	WatchRates(*WatchRatesRequest, CurrencyConverter_WatchRatesServer) error
+	ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error)
+	GetRate(context.Context, *GetRateRequest) (*GetRateResponse, error)
+	// GetRateHistory returns the OHLC series of the cross rate between start and end
+	GetRateHistory(context.Context, *GetRateHistoryRequest) (*GetRateHistoryResponse, error)
+	// CreateQuote locks the current cross rate for the configured time to live
+	CreateQuote(context.Context, *CreateQuoteRequest) (*Quote, error)
+	// ConvertWithQuote converts at the rate locked by the quote, fails with FAILED_PRECONDITION once it expired
+	ConvertWithQuote(context.Context, *ConvertWithQuoteRequest) (*ConvertResponse, error)
 	mustEmbedUnimplementedCurrencyConverterServer()
 }
 
@@ -53,6 +159,27 @@ type UnimplementedCurrencyConverterServer struct {
 func (UnimplementedCurrencyConverterServer) Convert(context.Context, *ConvertRequest) (*ConvertResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Convert not implemented")
 }
+func (UnimplementedCurrencyConverterServer) BatchConvert(context.Context, *BatchConvertRequest) (*BatchConvertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchConvert not implemented")
+}
+func (UnimplementedCurrencyConverterServer) WatchRates(*WatchRatesRequest, CurrencyConverter_WatchRatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRates not implemented")
+}
+func (UnimplementedCurrencyConverterServer) ListCurrencies(context.Context, *ListCurrenciesRequest) (*ListCurrenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCurrencies not implemented")
+}
+func (UnimplementedCurrencyConverterServer) GetRate(context.Context, *GetRateRequest) (*GetRateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRate not implemented")
+}
+func (UnimplementedCurrencyConverterServer) GetRateHistory(context.Context, *GetRateHistoryRequest) (*GetRateHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRateHistory not implemented")
+}
+func (UnimplementedCurrencyConverterServer) CreateQuote(context.Context, *CreateQuoteRequest) (*Quote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateQuote not implemented")
+}
+func (UnimplementedCurrencyConverterServer) ConvertWithQuote(context.Context, *ConvertWithQuoteRequest) (*ConvertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertWithQuote not implemented")
+}
 func (UnimplementedCurrencyConverterServer) mustEmbedUnimplementedCurrencyConverterServer() {}
 
 // UnsafeCurrencyConverterServer may be embedded to opt out of forward compatibility for this service.
@@ -84,6 +211,135 @@ func _CurrencyConverter_Convert_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CurrencyConverter_BatchConvert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyConverterServer).BatchConvert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currencyconverter.CurrencyConverter/BatchConvert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyConverterServer).BatchConvert(ctx, req.(*BatchConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyConverter_WatchRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CurrencyConverterServer).WatchRates(m, &currencyConverterWatchRatesServer{stream})
+}
+
+type CurrencyConverter_WatchRatesServer interface {
+	Send(*RatesSnapshot) error
+	grpc.ServerStream
+}
+
+type currencyConverterWatchRatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *currencyConverterWatchRatesServer) Send(m *RatesSnapshot) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CurrencyConverter_ListCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCurrenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyConverterServer).ListCurrencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currencyconverter.CurrencyConverter/ListCurrencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyConverterServer).ListCurrencies(ctx, req.(*ListCurrenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyConverter_GetRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyConverterServer).GetRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currencyconverter.CurrencyConverter/GetRate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyConverterServer).GetRate(ctx, req.(*GetRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyConverter_GetRateHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRateHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyConverterServer).GetRateHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currencyconverter.CurrencyConverter/GetRateHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyConverterServer).GetRateHistory(ctx, req.(*GetRateHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyConverter_CreateQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyConverterServer).CreateQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currencyconverter.CurrencyConverter/CreateQuote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyConverterServer).CreateQuote(ctx, req.(*CreateQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyConverter_ConvertWithQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertWithQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyConverterServer).ConvertWithQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/currencyconverter.CurrencyConverter/ConvertWithQuote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyConverterServer).ConvertWithQuote(ctx, req.(*ConvertWithQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CurrencyConverter_ServiceDesc is the grpc.ServiceDesc for CurrencyConverter service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -95,7 +351,37 @@ var CurrencyConverter_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Convert",
 			Handler:    _CurrencyConverter_Convert_Handler,
 		},
+		{
+			MethodName: "BatchConvert",
+			Handler:    _CurrencyConverter_BatchConvert_Handler,
+		},
+		{
+			MethodName: "ListCurrencies",
+			Handler:    _CurrencyConverter_ListCurrencies_Handler,
+		},
+		{
+			MethodName: "GetRate",
+			Handler:    _CurrencyConverter_GetRate_Handler,
+		},
+		{
+			MethodName: "GetRateHistory",
+			Handler:    _CurrencyConverter_GetRateHistory_Handler,
+		},
+		{
+			MethodName: "CreateQuote",
+			Handler:    _CurrencyConverter_CreateQuote_Handler,
+		},
+		{
+			MethodName: "ConvertWithQuote",
+			Handler:    _CurrencyConverter_ConvertWithQuote_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchRates",
+			Handler:       _CurrencyConverter_WatchRates_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/currency_converter.proto",
 }
@@ -0,0 +1,52 @@
+package clients
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/go-masonry/mortar/interfaces/cfg"
+)
+
+// AssetClassConfig is the configuration of an asset class fetched separately from the fiat currencies
+type AssetClassConfig struct {
+	Class model.AssetClass `mapstructure:"-"`
+	// Providers fetching the class, in failover order
+	Providers    []string `mapstructure:"providers"`
+	CronSchedule string   `mapstructure:"cronSchedule"`
+	// Collection the class rates are stored in
+	Collection string `mapstructure:"collection"`
+}
+
+const assetClassesKey = "exchangerate.assetClasses"
+
+// ReadAssetClasses returns the asset classes configured with providers of their own, sorted by class.
+// The fiat class isn't one of them, it's fetched by the rest of the providers.
+func ReadAssetClasses(config cfg.Config) (result []AssetClassConfig, err error) {
+	var configs map[string]AssetClassConfig
+	if err = config.Get(assetClassesKey).Unmarshal(&configs); err != nil {
+		err = fmt.Errorf("invalid %s configuration: %w", assetClassesKey, err)
+		return
+	}
+	for name, classConfig := range configs {
+		if classConfig.Class, err = model.ParseAssetClass(name); err != nil {
+			return
+		}
+		if classConfig.Class == model.AssetClassFiat {
+			err = fmt.Errorf("the fiat class is fetched by the providers that aren't assigned to another class")
+			return
+		}
+		if len(classConfig.Providers) == 0 {
+			continue
+		}
+		if len(classConfig.Collection) == 0 {
+			err = fmt.Errorf("asset class %s has no collection", name)
+			return
+		}
+		result = append(result, classConfig)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Class < result[j].Class
+	})
+	return
+}
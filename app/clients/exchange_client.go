@@ -2,40 +2,39 @@ package clients
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"time"
+
 	"github.com/bevgene/go-currency-rate/app/model"
-	"github.com/go-masonry/mortar/interfaces/cfg"
-	"github.com/go-masonry/mortar/interfaces/http/client"
 	"github.com/go-masonry/mortar/interfaces/log"
 	"go.uber.org/fx"
-	"io/ioutil"
-	"net/http"
 )
 
 //go:generate mockgen -source=exchange_client.go -destination=mock/exchange_client_mock.go
 
 type (
 	ExchangeClient interface {
-		GetRates(context.Context) (*model.ExchangeRatesModel, error)
+		// Providers returns the names of the configured providers in their failover order
+		Providers() []string
+		GetRates(ctx context.Context, provider string) (*model.ExchangeRatesModel, error)
+		// HistoricalProviders returns the names of the providers that serve past rates, in their failover order
+		HistoricalProviders() []string
+		GetHistoricalRates(ctx context.Context, provider string, date time.Time) (*model.ExchangeRatesModel, error)
 	}
 
 	exchangeClientImplDeps struct {
 		fx.In
 
-		Logger            log.Logger
-		Config            cfg.Config
-		Lifecycle         fx.Lifecycle
-		HTTPClientBuilder client.NewHTTPClientBuilder
+		Logger    log.Logger
+		Providers RateProviders
 	}
 
 	exchangeClientImpl struct {
-		deps   exchangeClientImplDeps
-		client *http.Client
-		url    string
+		deps exchangeClientImplDeps
 	}
 )
 
+// Legacy single provider configuration, used when exchangerate.providers is empty
 const (
 	exchangeAPIKeyKey  = "exchangerate.exchange.apiKey"
 	exchangeUrlKey     = "exchangerate.exchange.url"
@@ -43,59 +42,76 @@ const (
 )
 
 func CreateExchangeClient(deps exchangeClientImplDeps) (result ExchangeClient, err error) {
-	url := deps.Config.Get(exchangeUrlKey).String()
-	apiKey := deps.Config.Get(exchangeAPIKeyKey).String()
-	timeout := deps.Config.Get(exchangeTimeoutKey).Duration()
-
-	authUrl := fmt.Sprintf("%s?access_key=%s", url, apiKey)
-	httpClient := deps.HTTPClientBuilder().WithPreconfiguredClient(&http.Client{Timeout: timeout}).Build()
-	deps.Lifecycle.Append(fx.Hook{
-		OnStart: func(ctx context.Context) (err error) {
-			var req *http.Request
-			if req, err = http.NewRequest("GET", authUrl, nil); err != nil {
-				return
-			}
-			_, err = httpClient.Do(req)
-			return
-		},
-	})
+	if len(deps.Providers.All()) == 0 {
+		err = fmt.Errorf("no rate providers configured")
+		return
+	}
 	result = &exchangeClientImpl{
-		deps:   deps,
-		client: httpClient,
-		url:    authUrl,
+		deps: deps,
 	}
 	return
 }
 
-func (impl *exchangeClientImpl) GetRates(ctx context.Context) (result *model.ExchangeRatesModel, err error) {
-	var req *http.Request
-	if req, err = http.NewRequest("GET", impl.url, nil); err != nil {
-		impl.deps.Logger.WithError(err).Error(ctx, "failed to create a new request")
-		return
+func (impl *exchangeClientImpl) Providers() []string {
+	providers := impl.deps.Providers.All()
+	result := make([]string, 0, len(providers))
+	for _, provider := range providers {
+		result = append(result, provider.Name())
 	}
+	return result
+}
+
+func (impl *exchangeClientImpl) HistoricalProviders() (result []string) {
+	for _, provider := range impl.deps.Providers.All() {
+		if _, ok := provider.(HistoricalRateProvider); ok {
+			result = append(result, provider.Name())
+		}
+	}
+	return
+}
 
-	var res *http.Response
-	if res, err = impl.client.Do(req); err != nil {
-		impl.deps.Logger.WithError(err).Error(ctx, "failed to execute request")
+// GetRates fetches the rates from the named provider, a response that isn't successful is an error
+func (impl *exchangeClientImpl) GetRates(ctx context.Context, providerName string) (result *model.ExchangeRatesModel, err error) {
+	provider, ok := impl.deps.Providers.Get(providerName)
+	if !ok {
+		err = newProviderError(providerName, ErrUnknownProvider, nil, "provider isn't configured")
 		return
 	}
+	var rates *model.ExchangeRatesModel
+	if rates, err = provider.GetRates(ctx); err != nil {
+		impl.deps.Logger.WithError(err).WithField("provider", providerName).Error(ctx, "failed fetching rates")
+		return
+	}
+	return impl.successful(ctx, providerName, rates)
+}
 
-	defer func() {
-		var closeErr error
-		if closeErr = res.Body.Close(); closeErr != nil {
-			impl.deps.Logger.WithError(closeErr).Error(ctx, "failed to close response body")
-		}
-	}()
-	var body []byte
-	if body, err = ioutil.ReadAll(res.Body); err != nil {
-		impl.deps.Logger.WithError(err).Error(ctx, "failed to read response body")
+// GetHistoricalRates fetches the rates of a past date from the named provider, it must be a HistoricalRateProvider
+func (impl *exchangeClientImpl) GetHistoricalRates(ctx context.Context, providerName string, date time.Time) (result *model.ExchangeRatesModel, err error) {
+	provider, ok := impl.deps.Providers.Get(providerName)
+	if !ok {
+		err = newProviderError(providerName, ErrUnknownProvider, nil, "provider isn't configured")
+		return
+	}
+	historicalProvider, ok := provider.(HistoricalRateProvider)
+	if !ok {
+		err = newProviderError(providerName, ErrUnknownProvider, nil, "provider doesn't serve historical rates")
+		return
+	}
+	var rates *model.ExchangeRatesModel
+	if rates, err = historicalProvider.GetHistoricalRates(ctx, date); err != nil {
+		impl.deps.Logger.WithError(err).WithField("provider", providerName).WithField("date", date).Error(ctx, "failed fetching historical rates")
 		return
 	}
-	var parsedRates model.ExchangeRatesModel
-	if err = json.Unmarshal(body, &parsedRates); err != nil {
-		impl.deps.Logger.WithError(err).Error(ctx, "failed to parse response body")
+	return impl.successful(ctx, providerName, rates)
+}
+
+// successful returns the rates unless the provider reported the response as unsuccessful
+func (impl *exchangeClientImpl) successful(ctx context.Context, providerName string, rates *model.ExchangeRatesModel) (result *model.ExchangeRatesModel, err error) {
+	if !rates.Success {
+		err = newProviderError(providerName, ErrMalformedBody, nil, "unsuccessful response")
+		impl.deps.Logger.WithError(err).Error(ctx, "failed fetching rates")
 		return
 	}
-	result = &parsedRates
+	result = rates
 	return
 }
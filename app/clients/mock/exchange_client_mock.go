@@ -9,6 +9,7 @@ import (
 	model "github.com/bevgene/go-currency-rate/app/model"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+	time "time"
 )
 
 // MockExchangeClient is a mock of ExchangeClient interface
@@ -34,17 +35,60 @@ func (m *MockExchangeClient) EXPECT() *MockExchangeClientMockRecorder {
 	return m.recorder
 }
 
+// Providers mocks base method
+func (m *MockExchangeClient) Providers() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Providers")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Providers indicates an expected call of Providers
+func (mr *MockExchangeClientMockRecorder) Providers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Providers", reflect.TypeOf((*MockExchangeClient)(nil).Providers))
+}
+
 // GetRates mocks base method
-func (m *MockExchangeClient) GetRates(arg0 context.Context) (*model.ExchangeRatesModel, error) {
+func (m *MockExchangeClient) GetRates(ctx context.Context, provider string) (*model.ExchangeRatesModel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRates", arg0)
+	ret := m.ctrl.Call(m, "GetRates", ctx, provider)
 	ret0, _ := ret[0].(*model.ExchangeRatesModel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRates indicates an expected call of GetRates
-func (mr *MockExchangeClientMockRecorder) GetRates(arg0 interface{}) *gomock.Call {
+func (mr *MockExchangeClientMockRecorder) GetRates(ctx, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockExchangeClient)(nil).GetRates), ctx, provider)
+}
+
+// HistoricalProviders mocks base method
+func (m *MockExchangeClient) HistoricalProviders() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HistoricalProviders")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// HistoricalProviders indicates an expected call of HistoricalProviders
+func (mr *MockExchangeClientMockRecorder) HistoricalProviders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HistoricalProviders", reflect.TypeOf((*MockExchangeClient)(nil).HistoricalProviders))
+}
+
+// GetHistoricalRates mocks base method
+func (m *MockExchangeClient) GetHistoricalRates(ctx context.Context, provider string, date time.Time) (*model.ExchangeRatesModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoricalRates", ctx, provider, date)
+	ret0, _ := ret[0].(*model.ExchangeRatesModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoricalRates indicates an expected call of GetHistoricalRates
+func (mr *MockExchangeClientMockRecorder) GetHistoricalRates(ctx, provider, date interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRates", reflect.TypeOf((*MockExchangeClient)(nil).GetRates), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricalRates", reflect.TypeOf((*MockExchangeClient)(nil).GetHistoricalRates), ctx, provider, date)
 }
@@ -9,9 +9,10 @@ import (
 	model "github.com/bevgene/go-currency-rate/app/model"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+	time "time"
 )
 
-// MockMongoClient is a mock of LazyMongoClient interface
+// MockMongoClient is a mock of MongoClient interface
 type MockMongoClient struct {
 	ctrl     *gomock.Controller
 	recorder *MockMongoClientMockRecorder
@@ -63,6 +64,124 @@ func (mr *MockMongoClientMockRecorder) GetLatestRateDocument(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestRateDocument", reflect.TypeOf((*MockMongoClient)(nil).GetLatestRateDocument), arg0)
 }
 
+// GetRateDocumentAt mocks base method
+func (m *MockMongoClient) GetRateDocumentAt(arg0 context.Context, arg1 time.Time) (*model.ExchangeRateDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateDocumentAt", arg0, arg1)
+	ret0, _ := ret[0].(*model.ExchangeRateDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateDocumentAt indicates an expected call of GetRateDocumentAt
+func (mr *MockMongoClientMockRecorder) GetRateDocumentAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateDocumentAt", reflect.TypeOf((*MockMongoClient)(nil).GetRateDocumentAt), arg0, arg1)
+}
+
+// GetRateDocumentTimes mocks base method
+func (m *MockMongoClient) GetRateDocumentTimes(ctx context.Context, start, end time.Time) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateDocumentTimes", ctx, start, end)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateDocumentTimes indicates an expected call of GetRateDocumentTimes
+func (mr *MockMongoClientMockRecorder) GetRateDocumentTimes(ctx, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateDocumentTimes", reflect.TypeOf((*MockMongoClient)(nil).GetRateDocumentTimes), ctx, start, end)
+}
+
+// GetLatestAssetClassRateDocument mocks base method
+func (m *MockMongoClient) GetLatestAssetClassRateDocument(arg0 context.Context, arg1 model.AssetClass) (*model.ExchangeRateDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestAssetClassRateDocument", arg0, arg1)
+	ret0, _ := ret[0].(*model.ExchangeRateDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestAssetClassRateDocument indicates an expected call of GetLatestAssetClassRateDocument
+func (mr *MockMongoClientMockRecorder) GetLatestAssetClassRateDocument(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestAssetClassRateDocument", reflect.TypeOf((*MockMongoClient)(nil).GetLatestAssetClassRateDocument), arg0, arg1)
+}
+
+// GetAssetClassRateDocumentAt mocks base method
+func (m *MockMongoClient) GetAssetClassRateDocumentAt(arg0 context.Context, arg1 model.AssetClass, arg2 time.Time) (*model.ExchangeRateDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetClassRateDocumentAt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ExchangeRateDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetClassRateDocumentAt indicates an expected call of GetAssetClassRateDocumentAt
+func (mr *MockMongoClientMockRecorder) GetAssetClassRateDocumentAt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetClassRateDocumentAt", reflect.TypeOf((*MockMongoClient)(nil).GetAssetClassRateDocumentAt), arg0, arg1, arg2)
+}
+
+// GetRateHistory mocks base method
+func (m *MockMongoClient) GetRateHistory(ctx context.Context, currencyFrom, currencyTo string, start, end time.Time, interval model.RateHistoryInterval) ([]model.RateHistoryPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateHistory", ctx, currencyFrom, currencyTo, start, end, interval)
+	ret0, _ := ret[0].([]model.RateHistoryPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateHistory indicates an expected call of GetRateHistory
+func (mr *MockMongoClientMockRecorder) GetRateHistory(ctx, currencyFrom, currencyTo, start, end, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateHistory", reflect.TypeOf((*MockMongoClient)(nil).GetRateHistory), ctx, currencyFrom, currencyTo, start, end, interval)
+}
+
+// AddQuoteDocument mocks base method
+func (m *MockMongoClient) AddQuoteDocument(arg0 context.Context, arg1 *model.QuoteDocument) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddQuoteDocument", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddQuoteDocument indicates an expected call of AddQuoteDocument
+func (mr *MockMongoClientMockRecorder) AddQuoteDocument(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddQuoteDocument", reflect.TypeOf((*MockMongoClient)(nil).AddQuoteDocument), arg0, arg1)
+}
+
+// GetQuoteDocument mocks base method
+func (m *MockMongoClient) GetQuoteDocument(ctx context.Context, id string) (*model.QuoteDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuoteDocument", ctx, id)
+	ret0, _ := ret[0].(*model.QuoteDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuoteDocument indicates an expected call of GetQuoteDocument
+func (mr *MockMongoClientMockRecorder) GetQuoteDocument(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuoteDocument", reflect.TypeOf((*MockMongoClient)(nil).GetQuoteDocument), ctx, id)
+}
+
+// AddQuarantinedRatesDocument mocks base method
+func (m *MockMongoClient) AddQuarantinedRatesDocument(arg0 context.Context, arg1 *model.QuarantinedRatesDocument) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddQuarantinedRatesDocument", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddQuarantinedRatesDocument indicates an expected call of AddQuarantinedRatesDocument
+func (mr *MockMongoClientMockRecorder) AddQuarantinedRatesDocument(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddQuarantinedRatesDocument", reflect.TypeOf((*MockMongoClient)(nil).AddQuarantinedRatesDocument), arg0, arg1)
+}
+
 // Disconnect mocks base method
 func (m *MockMongoClient) Disconnect(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -2,6 +2,7 @@ package clients
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/bevgene/go-currency-rate/app/model"
 	"github.com/go-masonry/mortar/interfaces/cfg"
@@ -11,6 +12,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/fx"
 	"net"
+	"time"
 )
 
 //go:generate mockgen -source=mongo_client.go -destination=mock/mongo_client_mock.go
@@ -31,13 +33,29 @@ type (
 	MongoClient interface {
 		AddRateDocument(context.Context, *model.ExchangeRateDocument) error
 		GetLatestRateDocument(context.Context) (*model.ExchangeRateDocument, error)
+		GetRateDocumentAt(context.Context, time.Time) (*model.ExchangeRateDocument, error)
+		// GetRateDocumentTimes returns the creation times of the fiat documents created within [start, end) in ascending order
+		GetRateDocumentTimes(ctx context.Context, start, end time.Time) ([]time.Time, error)
+		// GetLatestAssetClassRateDocument returns the newest document of the asset class, nil if there is none
+		GetLatestAssetClassRateDocument(context.Context, model.AssetClass) (*model.ExchangeRateDocument, error)
+		// GetAssetClassRateDocumentAt returns the newest document of the asset class created at or before the given time
+		GetAssetClassRateDocumentAt(context.Context, model.AssetClass, time.Time) (*model.ExchangeRateDocument, error)
+		GetRateHistory(ctx context.Context, currencyFrom, currencyTo string, start, end time.Time, interval model.RateHistoryInterval) ([]model.RateHistoryPoint, error)
+		AddQuoteDocument(context.Context, *model.QuoteDocument) error
+		GetQuoteDocument(ctx context.Context, id string) (*model.QuoteDocument, error)
+		AddQuarantinedRatesDocument(context.Context, *model.QuarantinedRatesDocument) error
 		Disconnect(ctx context.Context) error
 	}
 
 	mongoClientImpl struct {
-		deps       mongoClientImplDeps
-		client     *mongo.Client
-		collection *mongo.Collection
+		deps             mongoClientImplDeps
+		client           *mongo.Client
+		collection       *mongo.Collection
+		quotesCollection *mongo.Collection
+		// quarantineCollection holds the rates documents that failed validation
+		quarantineCollection *mongo.Collection
+		// classCollections holds the rates of the asset classes fetched separately from the fiat ones
+		classCollections map[model.AssetClass]*mongo.Collection
 	}
 )
 
@@ -49,6 +67,12 @@ const (
 	passwordKey   = "exchangerate.database.password"
 	databaseKey   = "exchangerate.database.name"
 	collectionKey = "exchangerate.database.collection"
+	quotesKey     = "exchangerate.database.quotesCollection"
+	quarantineKey = "exchangerate.database.quarantineCollection"
+	// quotesRetentionKey is how long expired quotes are kept, so that they're answered as expired rather than missing
+	quotesRetentionKey = "exchangerate.quotes.retention"
+	// indexOptionsConflictCode is returned by mongo when an index exists with other options
+	indexOptionsConflictCode = 85
 )
 
 func CreateMongoClient(deps mongoClientImplDeps) (result *LazyMongoClient, err error) {
@@ -59,6 +83,17 @@ func CreateMongoClient(deps mongoClientImplDeps) (result *LazyMongoClient, err e
 	userName := deps.Config.Get(userKey).String()
 	password := deps.Config.Get(passwordKey).String()
 	collectionName := deps.Config.Get(collectionKey).String()
+	quotesCollectionName := deps.Config.Get(quotesKey).String()
+	quarantineCollectionName := deps.Config.Get(quarantineKey).String()
+	quotesRetention := deps.Config.Get(quotesRetentionKey).Duration()
+	if quotesRetention < 0 {
+		err = fmt.Errorf("%s can't be negative, got %s", quotesRetentionKey, quotesRetention)
+		return
+	}
+	var assetClasses []AssetClassConfig
+	if assetClasses, err = ReadAssetClasses(deps.Config); err != nil {
+		return
+	}
 
 	uri := fmt.Sprintf("mongodb://%s/%s", net.JoinHostPort(host, port), dbName)
 	if len(userName) > 0 && len(password) > 0 {
@@ -67,7 +102,7 @@ func CreateMongoClient(deps mongoClientImplDeps) (result *LazyMongoClient, err e
 	clientOptions := options.Client().ApplyURI(uri).SetAppName(appName)
 	var clientPtr = new(LazyMongoClient)
 	var mongoClient *mongo.Client
-	var collection *mongo.Collection
+	var collection, quotesCollection *mongo.Collection
 	deps.Lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) (startError error) {
 			if mongoClient, startError = mongo.Connect(ctx, clientOptions); startError != nil {
@@ -79,18 +114,35 @@ func CreateMongoClient(deps mongoClientImplDeps) (result *LazyMongoClient, err e
 			}
 			collection = mongoClient.Database(dbName).Collection(collectionName)
 			indexModel := mongo.IndexModel{
-				Keys:    bson.D{{"created_at", 1}},
+				Keys:    bson.D{{Key: "created_at", Value: 1}},
 				Options: options.Index().SetUnique(true),
 			}
 
 			if _, startError = collection.Indexes().CreateOne(ctx, indexModel); startError != nil {
 				return
 			}
+			classCollections := make(map[model.AssetClass]*mongo.Collection, len(assetClasses))
+			for _, assetClass := range assetClasses {
+				classCollection := mongoClient.Database(dbName).Collection(assetClass.Collection)
+				if _, startError = classCollection.Indexes().CreateOne(ctx, indexModel); startError != nil {
+					return
+				}
+				classCollections[assetClass.Class] = classCollection
+			}
+
+			quotesCollection = mongoClient.Database(dbName).Collection(quotesCollectionName)
+			// expired quotes are removed by mongo once they're past the retention, expiry must still be checked on read
+			if startError = ensureTTLIndex(ctx, mongoClient.Database(dbName), quotesCollectionName, "expires_at", quotesRetention); startError != nil {
+				return
+			}
 
 			clientPtr.Client = &mongoClientImpl{
-				deps:       deps,
-				client:     mongoClient,
-				collection: collection,
+				deps:                 deps,
+				client:               mongoClient,
+				collection:           collection,
+				quotesCollection:     quotesCollection,
+				quarantineCollection: mongoClient.Database(dbName).Collection(quarantineCollectionName),
+				classCollections:     classCollections,
 			}
 			return
 		},
@@ -108,23 +160,200 @@ func CreateMongoClient(deps mongoClientImplDeps) (result *LazyMongoClient, err e
 }
 
 func (impl *mongoClientImpl) AddRateDocument(ctx context.Context, document *model.ExchangeRateDocument) (err error) {
-	_, err = impl.collection.InsertOne(ctx, document)
+	var collection *mongo.Collection
+	if collection, err = impl.rateCollection(document.AssetClass); err != nil {
+		return
+	}
+	_, err = collection.InsertOne(ctx, document)
 	impl.deps.Logger.WithError(err).WithField("document", document).Error(ctx, "add rate document")
 	return
 }
 
-func (impl *mongoClientImpl) GetLatestRateDocument(ctx context.Context) (result *model.ExchangeRateDocument, err error) {
+// GetLatestRateDocument returns the newest fiat document, nil if no rates were stored yet
+func (impl *mongoClientImpl) GetLatestRateDocument(ctx context.Context) (*model.ExchangeRateDocument, error) {
+	return impl.findNewestRateDocument(ctx, impl.collection, bson.M{})
+}
+
+// GetRateDocumentAt returns the newest document created at or before the given time, nil if there is none
+func (impl *mongoClientImpl) GetRateDocumentAt(ctx context.Context, at time.Time) (*model.ExchangeRateDocument, error) {
+	return impl.findNewestRateDocument(ctx, impl.collection, bson.M{"created_at": bson.M{"$lte": at}})
+}
+
+func (impl *mongoClientImpl) GetRateDocumentTimes(ctx context.Context, start, end time.Time) (result []time.Time, err error) {
+	findOptions := options.Find().SetSort(bson.M{"created_at": 1}).SetProjection(bson.M{"created_at": 1})
+	var cursor *mongo.Cursor
+	if cursor, err = impl.collection.Find(ctx, bson.M{"created_at": bson.M{"$gte": start, "$lt": end}}, findOptions); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "failed finding rate documents")
+		return
+	}
+	var docs []struct {
+		CreatedAt time.Time `bson:"created_at"`
+	}
+	if err = cursor.All(ctx, &docs); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "failed decoding rate documents")
+		return
+	}
+	result = make([]time.Time, 0, len(docs))
+	for _, doc := range docs {
+		result = append(result, doc.CreatedAt)
+	}
+	return
+}
+
+func (impl *mongoClientImpl) GetLatestAssetClassRateDocument(ctx context.Context, class model.AssetClass) (result *model.ExchangeRateDocument, err error) {
+	var collection *mongo.Collection
+	if collection, err = impl.rateCollection(class); err != nil {
+		return
+	}
+	return impl.findNewestRateDocument(ctx, collection, bson.M{})
+}
+
+func (impl *mongoClientImpl) GetAssetClassRateDocumentAt(ctx context.Context, class model.AssetClass, at time.Time) (result *model.ExchangeRateDocument, err error) {
+	var collection *mongo.Collection
+	if collection, err = impl.rateCollection(class); err != nil {
+		return
+	}
+	return impl.findNewestRateDocument(ctx, collection, bson.M{"created_at": bson.M{"$lte": at}})
+}
+
+// findNewestRateDocument returns the newest document matching the filter, nil if there is none
+func (impl *mongoClientImpl) findNewestRateDocument(ctx context.Context, collection *mongo.Collection, filter bson.M) (result *model.ExchangeRateDocument, err error) {
 	findOneOptions := options.FindOne()
 	findOneOptions.SetSort(bson.M{"created_at": -1})
 	var doc model.ExchangeRateDocument
-	if err = impl.collection.FindOne(ctx, bson.M{}, findOneOptions).Decode(&doc); err != nil {
-		impl.deps.Logger.WithError(err).Error(ctx, "failed decoding result")
+	if err = collection.FindOne(ctx, filter, findOneOptions).Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			err = nil
+			return
+		}
+		impl.deps.Logger.WithError(err).WithField("filter", filter).Error(ctx, "failed decoding result")
 		return
 	}
 	result = &doc
 	return
 }
 
+// rateCollection returns the collection of the asset class rates, the fiat rates are in the main collection
+func (impl *mongoClientImpl) rateCollection(class model.AssetClass) (*mongo.Collection, error) {
+	if len(class) == 0 || class == model.AssetClassFiat {
+		return impl.collection, nil
+	}
+	if collection, ok := impl.classCollections[class]; ok {
+		return collection, nil
+	}
+	return nil, fmt.Errorf("asset class %s isn't configured", class)
+}
+
+// GetRateHistory aggregates the currencyTo/currencyFrom cross rate of the documents created within [start, end) into
+// OHLC points, one per interval bucket, ordered by the bucket start
+func (impl *mongoClientImpl) GetRateHistory(ctx context.Context, currencyFrom, currencyTo string, start, end time.Time, interval model.RateHistoryInterval) (result []model.RateHistoryPoint, err error) {
+	rateFrom, rateTo := "$rates."+currencyFrom, "$rates."+currencyTo
+	createdAt := bson.M{"$toLong": "$created_at"}
+	// a raw bucket is the document itself, otherwise the creation time is truncated to the bucket start
+	bucket := interface{}(createdAt)
+	if size, offset := historyBucket(interval); size > 0 {
+		bucket = bson.M{"$subtract": bson.A{createdAt, bson.M{"$mod": bson.A{bson.M{"$subtract": bson.A{createdAt, offset}}, size}}}}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"created_at":            bson.M{"$gte": start, "$lt": end},
+			"rates." + currencyFrom: bson.M{"$gt": 0},
+			"rates." + currencyTo:   bson.M{"$exists": true},
+		}}},
+		{{Key: "$sort", Value: bson.M{"created_at": 1}}},
+		{{Key: "$project", Value: bson.M{
+			"bucket": bucket,
+			"rate":   bson.M{"$divide": bson.A{rateTo, rateFrom}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     bson.M{"$toDate": "$bucket"},
+			"open":    bson.M{"$first": "$rate"},
+			"high":    bson.M{"$max": "$rate"},
+			"low":     bson.M{"$min": "$rate"},
+			"close":   bson.M{"$last": "$rate"},
+			"samples": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+	var cursor *mongo.Cursor
+	if cursor, err = impl.collection.Aggregate(ctx, pipeline); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "failed aggregating rate history")
+		return
+	}
+	result = make([]model.RateHistoryPoint, 0)
+	if err = cursor.All(ctx, &result); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "failed decoding rate history")
+	}
+	return
+}
+
+// historyBucket returns the bucket size and its offset from the unix epoch in milliseconds, 0 size means no bucketing
+func historyBucket(interval model.RateHistoryInterval) (size, offset int64) {
+	switch interval {
+	case model.IntervalHourly:
+		size = time.Hour.Milliseconds()
+	case model.IntervalDaily:
+		size = (24 * time.Hour).Milliseconds()
+	case model.IntervalWeekly:
+		size = (7 * 24 * time.Hour).Milliseconds()
+		// the unix epoch is a Thursday, weeks start on the following Monday
+		offset = (4 * 24 * time.Hour).Milliseconds()
+	}
+	return
+}
+
+// ensureTTLIndex creates the TTL index of the field, an existing index of another expiry is updated in place
+func ensureTTLIndex(ctx context.Context, database *mongo.Database, collectionName, field string, expireAfter time.Duration) (err error) {
+	expireAfterSeconds := int32(expireAfter / time.Second)
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: field, Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(expireAfterSeconds),
+	}
+	if _, err = database.Collection(collectionName).Indexes().CreateOne(ctx, indexModel); err == nil {
+		return
+	}
+	var commandErr mongo.CommandError
+	if !errors.As(err, &commandErr) || commandErr.Code != indexOptionsConflictCode {
+		return
+	}
+	return database.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: collectionName},
+		{Key: "index", Value: bson.D{
+			{Key: "keyPattern", Value: bson.D{{Key: field, Value: 1}}},
+			{Key: "expireAfterSeconds", Value: expireAfterSeconds},
+		}},
+	}).Err()
+}
+
+func (impl *mongoClientImpl) AddQuoteDocument(ctx context.Context, document *model.QuoteDocument) (err error) {
+	if _, err = impl.quotesCollection.InsertOne(ctx, document); err != nil {
+		impl.deps.Logger.WithError(err).WithField("document", document).Error(ctx, "failed adding quote document")
+	}
+	return
+}
+
+// GetQuoteDocument returns the quote with the given id, nil if there is none
+func (impl *mongoClientImpl) GetQuoteDocument(ctx context.Context, id string) (result *model.QuoteDocument, err error) {
+	var doc model.QuoteDocument
+	if err = impl.quotesCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc); err != nil {
+		if err == mongo.ErrNoDocuments {
+			err = nil
+			return
+		}
+		impl.deps.Logger.WithError(err).WithField("id", id).Error(ctx, "failed decoding quote")
+		return
+	}
+	result = &doc
+	return
+}
+
+func (impl *mongoClientImpl) AddQuarantinedRatesDocument(ctx context.Context, document *model.QuarantinedRatesDocument) (err error) {
+	if _, err = impl.quarantineCollection.InsertOne(ctx, document); err != nil {
+		impl.deps.Logger.WithError(err).WithField("document", document).Error(ctx, "failed adding quarantined rates document")
+	}
+	return
+}
+
 func (impl *mongoClientImpl) Disconnect(ctx context.Context) error {
 	return impl.client.Disconnect(ctx)
 }
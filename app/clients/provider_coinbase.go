@@ -0,0 +1,74 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	// coinbaseProvider reads the exchange rates endpoint of https://api.coinbase.com/v2/exchange-rates, the base is set
+	// by the currency query parameter of the url. Any exchange API of the same format can be used, e.g. a local stand-in.
+	coinbaseProvider struct {
+		deps   rateProvidersImplDeps
+		name   string
+		client *http.Client
+		url    string
+	}
+
+	coinbaseResponse struct {
+		Data struct {
+			Currency string `json:"currency"`
+			// Rates are quoted as strings, crypto currencies need more precision than a float
+			Rates map[string]model.Decimal `json:"rates"`
+		} `json:"data"`
+	}
+)
+
+func createCoinbaseProvider(deps rateProvidersImplDeps, config providerConfig, httpClient *http.Client) (RateProvider, error) {
+	return &coinbaseProvider{
+		deps:   deps,
+		name:   config.Name,
+		client: httpClient,
+		url:    config.URL,
+	}, nil
+}
+
+func (impl *coinbaseProvider) Name() string {
+	return impl.name
+}
+
+func (impl *coinbaseProvider) GetRates(ctx context.Context) (result *model.ExchangeRatesModel, err error) {
+	var body []byte
+	if body, err = fetch(ctx, impl.deps.Logger, impl.client, impl.name, impl.url); err != nil {
+		return
+	}
+	var parsed coinbaseResponse
+	if err = json.Unmarshal(body, &parsed); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "failed to parse response body")
+		err = newProviderError(impl.name, ErrMalformedBody, err, "failed parsing response body")
+		return
+	}
+	// the prices are live, there is no timestamp in the response
+	now := time.Now().UTC()
+	rates := &model.ExchangeRatesModel{
+		Success:   true,
+		Date:      now.Format(dateLayout),
+		Base:      parsed.Data.Currency,
+		Timestamp: now.Unix(),
+		Rates:     parsed.Data.Rates,
+	}
+	if _, ok := rates.Rates[rates.Base]; !ok && len(rates.Rates) > 0 {
+		rates.Rates[rates.Base] = model.NewDecimal(decimal.NewFromInt(1))
+	}
+	if err = validateRates(impl.name, rates); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "provider returned invalid rates")
+		return
+	}
+	result = rates
+	return
+}
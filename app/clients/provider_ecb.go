@@ -0,0 +1,107 @@
+package clients
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+	// the publication time zone is available even where the system has no time zone database
+	_ "time/tzdata"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/shopspring/decimal"
+)
+
+type (
+	// ecbProvider reads the European Central Bank daily reference rates XML feed, the rates are relative to EUR
+	ecbProvider struct {
+		deps   rateProvidersImplDeps
+		name   string
+		client *http.Client
+		url    string
+		// location is the time zone the rates are published in
+		location *time.Location
+	}
+
+	ecbEnvelope struct {
+		Cube struct {
+			Cube struct {
+				Time  string `xml:"time,attr"`
+				Rates []struct {
+					Currency string `xml:"currency,attr"`
+					Rate     string `xml:"rate,attr"`
+				} `xml:"Cube"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	}
+)
+
+const (
+	ecbBase = "EUR"
+	// ECB publishes the reference rates around 16:00 Frankfurt time, the feed has the date only
+	ecbTimeZone        = "Europe/Berlin"
+	ecbPublicationHour = 16
+)
+
+func createECBProvider(deps rateProvidersImplDeps, config providerConfig, httpClient *http.Client) (result RateProvider, err error) {
+	var location *time.Location
+	if location, err = time.LoadLocation(ecbTimeZone); err != nil {
+		err = fmt.Errorf("failed loading the %s time zone of provider %s: %w", ecbTimeZone, config.Name, err)
+		return
+	}
+	result = &ecbProvider{
+		deps:     deps,
+		name:     config.Name,
+		client:   httpClient,
+		url:      config.URL,
+		location: location,
+	}
+	return
+}
+
+func (impl *ecbProvider) Name() string {
+	return impl.name
+}
+
+func (impl *ecbProvider) GetRates(ctx context.Context) (result *model.ExchangeRatesModel, err error) {
+	var body []byte
+	if body, err = fetch(ctx, impl.deps.Logger, impl.client, impl.name, impl.url); err != nil {
+		return
+	}
+	var envelope ecbEnvelope
+	if err = xml.Unmarshal(body, &envelope); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "failed to parse response body")
+		err = newProviderError(impl.name, ErrMalformedBody, err, "failed parsing response body")
+		return
+	}
+	var date time.Time
+	if date, err = time.ParseInLocation(dateLayout, envelope.Cube.Cube.Time, impl.location); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "failed to parse rates date")
+		err = newProviderError(impl.name, ErrMalformedBody, err, "invalid rates date")
+		return
+	}
+	result = &model.ExchangeRatesModel{
+		Success:   true,
+		Date:      envelope.Cube.Cube.Time,
+		Base:      ecbBase,
+		Timestamp: time.Date(date.Year(), date.Month(), date.Day(), ecbPublicationHour, 0, 0, 0, impl.location).Unix(),
+		Rates:     map[string]model.Decimal{ecbBase: model.NewDecimal(decimal.NewFromInt(1))},
+	}
+	for _, rate := range envelope.Cube.Cube.Rates {
+		var value decimal.Decimal
+		if value, err = decimal.NewFromString(rate.Rate); err != nil {
+			err = newProviderError(impl.name, ErrMalformedBody, err, "invalid %s rate %q", rate.Currency, rate.Rate)
+			impl.deps.Logger.WithError(err).Error(ctx, "failed to parse response body")
+			result = nil
+			return
+		}
+		result.Rates[rate.Currency] = model.NewDecimal(value)
+	}
+	if len(envelope.Cube.Cube.Rates) == 0 {
+		err = newProviderError(impl.name, ErrMalformedBody, nil, "missing rates")
+		impl.deps.Logger.WithError(err).Error(ctx, "provider returned invalid rates")
+		result = nil
+	}
+	return
+}
@@ -0,0 +1,98 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ProviderErrorType classifies upstream failures, it's also the Temporal ApplicationError type
+type ProviderErrorType string
+
+const (
+	ErrInvalidKey    ProviderErrorType = "InvalidKey"
+	ErrQuotaExceeded ProviderErrorType = "QuotaExceeded"
+	ErrRateLimited   ProviderErrorType = "RateLimited"
+	ErrUpstream      ProviderErrorType = "UpstreamUnavailable"
+	ErrMalformedBody ProviderErrorType = "MalformedBody"
+	// ErrUnknownProvider means the provider isn't configured anymore
+	ErrUnknownProvider ProviderErrorType = "UnknownProvider"
+	// ErrInvalidRequest means the request couldn't be built, e.g. the configured url is malformed
+	ErrInvalidRequest ProviderErrorType = "InvalidRequest"
+)
+
+// ProviderError is returned by every RateProvider failure
+type ProviderError struct {
+	Provider string
+	Type     ProviderErrorType
+	Message  string
+	Cause    error
+}
+
+func (e *ProviderError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s %s: %s: %v", e.Provider, e.Type, e.Message, e.Cause)
+	}
+	return fmt.Sprintf("%s %s: %s", e.Provider, e.Type, e.Message)
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Cause
+}
+
+// Retryable is false for failures that won't go away by retrying the same provider soon, such as an invalid key or
+// an exhausted quota, the next provider should be used instead
+func (e *ProviderError) Retryable() bool {
+	switch e.Type {
+	case ErrRateLimited, ErrUpstream:
+		return true
+	default:
+		return false
+	}
+}
+
+// AsProviderError returns the ProviderError wrapped by err, if any
+func AsProviderError(err error) (result *ProviderError, ok bool) {
+	ok = errors.As(err, &result)
+	return
+}
+
+func newProviderError(provider string, errorType ProviderErrorType, cause error, format string, args ...interface{}) *ProviderError {
+	return &ProviderError{
+		Provider: provider,
+		Type:     errorType,
+		Message:  fmt.Sprintf(format, args...),
+		Cause:    cause,
+	}
+}
+
+// statusErrorType classifies an unexpected http status code
+func statusErrorType(statusCode int) ProviderErrorType {
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ErrInvalidKey
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode >= http.StatusInternalServerError:
+		return ErrUpstream
+	default:
+		return ErrMalformedBody
+	}
+}
+
+// apiLayerErrorType classifies the error codes of fixer and exchangerate.host, both are served by apilayer
+func apiLayerErrorType(code int) ProviderErrorType {
+	switch code {
+	// missing or invalid access key, inactive account, endpoint not supported by the subscription plan
+	case 101, 102, 103, 105:
+		return ErrInvalidKey
+	// monthly request volume reached
+	case 104:
+		return ErrQuotaExceeded
+	// too many requests
+	case 429:
+		return ErrRateLimited
+	default:
+		return ErrUpstream
+	}
+}
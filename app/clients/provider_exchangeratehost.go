@@ -0,0 +1,84 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+)
+
+type (
+	// exchangeRateHostProvider reads https://exchangerate.host latest rates
+	exchangeRateHostProvider struct {
+		deps   rateProvidersImplDeps
+		name   string
+		client *http.Client
+		url    string
+	}
+
+	exchangeRateHostResponse struct {
+		Success bool   `json:"success"`
+		Base    string `json:"base"`
+		Date    string `json:"date"`
+		// Timestamp is missing from older API versions
+		Timestamp int64                    `json:"timestamp"`
+		Rates     map[string]model.Decimal `json:"rates"`
+		Error     *fixerError              `json:"error"`
+	}
+)
+
+func createExchangeRateHostProvider(deps rateProvidersImplDeps, config providerConfig, httpClient *http.Client) (RateProvider, error) {
+	providerURL := config.URL
+	if len(config.APIKey) > 0 {
+		providerURL = fmt.Sprintf("%s?access_key=%s", config.URL, url.QueryEscape(config.APIKey))
+	}
+	return &exchangeRateHostProvider{
+		deps:   deps,
+		name:   config.Name,
+		client: httpClient,
+		url:    providerURL,
+	}, nil
+}
+
+func (impl *exchangeRateHostProvider) Name() string {
+	return impl.name
+}
+
+func (impl *exchangeRateHostProvider) GetRates(ctx context.Context) (result *model.ExchangeRatesModel, err error) {
+	var body []byte
+	if body, err = fetch(ctx, impl.deps.Logger, impl.client, impl.name, impl.url); err != nil {
+		return
+	}
+	var parsed exchangeRateHostResponse
+	if err = json.Unmarshal(body, &parsed); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "failed to parse response body")
+		err = newProviderError(impl.name, ErrMalformedBody, err, "failed parsing response body")
+		return
+	}
+	if err = parsed.Error.providerError(impl.name, parsed.Success); err != nil {
+		impl.deps.Logger.WithError(err).WithField("body", string(body)).Error(ctx, "provider returned an error")
+		return
+	}
+	timestamp := parsed.Timestamp
+	if timestamp == 0 {
+		// rates are updated continuously, the date alone would collide with every other fetch of the same day
+		timestamp = time.Now().Unix()
+	}
+	rates := &model.ExchangeRatesModel{
+		Success:   true,
+		Date:      parsed.Date,
+		Base:      parsed.Base,
+		Timestamp: timestamp,
+		Rates:     parsed.Rates,
+	}
+	if err = validateRates(impl.name, rates); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "provider returned invalid rates")
+		return
+	}
+	result = rates
+	return
+}
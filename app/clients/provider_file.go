@@ -0,0 +1,65 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+)
+
+// fileProvider reads static rates from a local JSON file in the shape of model.ExchangeRatesModel (fixer format)
+type fileProvider struct {
+	deps rateProvidersImplDeps
+	name string
+	path string
+}
+
+func createFileProvider(deps rateProvidersImplDeps, config providerConfig, _ *http.Client) (RateProvider, error) {
+	if len(config.Path) == 0 {
+		return nil, fmt.Errorf("path cannot be empty")
+	}
+	return &fileProvider{
+		deps: deps,
+		name: config.Name,
+		path: config.Path,
+	}, nil
+}
+
+func (impl *fileProvider) Name() string {
+	return impl.name
+}
+
+func (impl *fileProvider) GetRates(ctx context.Context) (result *model.ExchangeRatesModel, err error) {
+	var body []byte
+	if body, err = ioutil.ReadFile(impl.path); err != nil {
+		impl.deps.Logger.WithError(err).WithField("path", impl.path).Error(ctx, "failed to read rates file")
+		err = newProviderError(impl.name, ErrUpstream, err, "failed reading %s", impl.path)
+		return
+	}
+	var parsed model.ExchangeRatesModel
+	if err = json.Unmarshal(body, &parsed); err != nil {
+		impl.deps.Logger.WithError(err).WithField("path", impl.path).Error(ctx, "failed to parse rates file")
+		err = newProviderError(impl.name, ErrMalformedBody, err, "failed parsing %s", impl.path)
+		return
+	}
+	if parsed.Timestamp == 0 {
+		// the file modification time is the best guess of when the rates were valid
+		var info os.FileInfo
+		if info, err = os.Stat(impl.path); err != nil {
+			err = newProviderError(impl.name, ErrUpstream, err, "failed reading %s", impl.path)
+			return
+		}
+		parsed.Timestamp = info.ModTime().Unix()
+	}
+	if err = validateRates(impl.name, &parsed); err != nil {
+		impl.deps.Logger.WithError(err).WithField("path", impl.path).Error(ctx, "invalid rates file")
+		return
+	}
+	parsed.Success = true
+	result = &parsed
+	return
+}
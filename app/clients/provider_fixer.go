@@ -0,0 +1,97 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+)
+
+type (
+	// fixerProvider reads https://fixer.io latest rates, its response is the shape of model.ExchangeRatesModel
+	fixerProvider struct {
+		deps   rateProvidersImplDeps
+		name   string
+		client *http.Client
+		url    string
+		// historicalURL is the base of the historical rates endpoints, the url without /latest
+		historicalURL string
+		accessKey     string
+	}
+
+	fixerResponse struct {
+		model.ExchangeRatesModel
+		Error *fixerError `json:"error"`
+	}
+
+	fixerError struct {
+		Code int    `json:"code"`
+		Type string `json:"type"`
+		Info string `json:"info"`
+	}
+)
+
+func createFixerProvider(deps rateProvidersImplDeps, config providerConfig, httpClient *http.Client) (RateProvider, error) {
+	accessKey := url.QueryEscape(config.APIKey)
+	return &fixerProvider{
+		deps:          deps,
+		name:          config.Name,
+		client:        httpClient,
+		url:           fmt.Sprintf("%s?access_key=%s", config.URL, accessKey),
+		historicalURL: strings.TrimSuffix(config.URL, "/latest"),
+		accessKey:     accessKey,
+	}, nil
+}
+
+func (impl *fixerProvider) Name() string {
+	return impl.name
+}
+
+func (impl *fixerProvider) GetRates(ctx context.Context) (*model.ExchangeRatesModel, error) {
+	return impl.get(ctx, impl.url)
+}
+
+// GetHistoricalRates reads the end of day rates of the given date from the /YYYY-MM-DD endpoint
+func (impl *fixerProvider) GetHistoricalRates(ctx context.Context, date time.Time) (*model.ExchangeRatesModel, error) {
+	return impl.get(ctx, fmt.Sprintf("%s/%s?access_key=%s", impl.historicalURL, date.UTC().Format(dateLayout), impl.accessKey))
+}
+
+func (impl *fixerProvider) get(ctx context.Context, providerURL string) (result *model.ExchangeRatesModel, err error) {
+	var body []byte
+	if body, err = fetch(ctx, impl.deps.Logger, impl.client, impl.name, providerURL); err != nil {
+		return
+	}
+	var parsed fixerResponse
+	if err = json.Unmarshal(body, &parsed); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "failed to parse response body")
+		err = newProviderError(impl.name, ErrMalformedBody, err, "failed parsing response body")
+		return
+	}
+	// fixer reports errors such as an exhausted quota with a 200 status code
+	if err = parsed.Error.providerError(impl.name, parsed.Success); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "provider returned an error")
+		return
+	}
+	if err = validateRates(impl.name, &parsed.ExchangeRatesModel); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "provider returned invalid rates")
+		return
+	}
+	result = &parsed.ExchangeRatesModel
+	return
+}
+
+// providerError returns nil for successful responses, fixer and exchangerate.host share the same error payload
+func (e *fixerError) providerError(provider string, success bool) error {
+	if success {
+		return nil
+	}
+	if e == nil {
+		return newProviderError(provider, ErrMalformedBody, nil, "unsuccessful response without an error")
+	}
+	return newProviderError(provider, apiLayerErrorType(e.Code), nil, "%d %s: %s", e.Code, e.Type, e.Info)
+}
@@ -0,0 +1,79 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+)
+
+type (
+	// openExchangeRatesProvider reads https://openexchangerates.org latest rates, the free plan only supports a USD base
+	openExchangeRatesProvider struct {
+		deps   rateProvidersImplDeps
+		name   string
+		client *http.Client
+		url    string
+	}
+
+	openExchangeRatesResponse struct {
+		Timestamp int64                    `json:"timestamp"`
+		Base      string                   `json:"base"`
+		Rates     map[string]model.Decimal `json:"rates"`
+		// Error responses come with a non 200 status code as well, which is what classifies them
+		Error       bool   `json:"error"`
+		Description string `json:"description"`
+	}
+)
+
+func createOpenExchangeRatesProvider(deps rateProvidersImplDeps, config providerConfig, httpClient *http.Client) (RateProvider, error) {
+	return &openExchangeRatesProvider{
+		deps:   deps,
+		name:   config.Name,
+		client: httpClient,
+		url:    fmt.Sprintf("%s?app_id=%s", config.URL, url.QueryEscape(config.APIKey)),
+	}, nil
+}
+
+func (impl *openExchangeRatesProvider) Name() string {
+	return impl.name
+}
+
+func (impl *openExchangeRatesProvider) GetRates(ctx context.Context) (result *model.ExchangeRatesModel, err error) {
+	var body []byte
+	if body, err = fetch(ctx, impl.deps.Logger, impl.client, impl.name, impl.url); err != nil {
+		// 429 access_restricted means the monthly requests allowance is used up, retrying won't help until it renews
+		if providerErr, ok := AsProviderError(err); ok && providerErr.Type == ErrRateLimited {
+			providerErr.Type = ErrQuotaExceeded
+		}
+		return
+	}
+	var parsed openExchangeRatesResponse
+	if err = json.Unmarshal(body, &parsed); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "failed to parse response body")
+		err = newProviderError(impl.name, ErrMalformedBody, err, "failed parsing response body")
+		return
+	}
+	if parsed.Error {
+		err = newProviderError(impl.name, ErrUpstream, nil, "request failed: %s", parsed.Description)
+		impl.deps.Logger.WithError(err).Error(ctx, "provider returned an error")
+		return
+	}
+	rates := &model.ExchangeRatesModel{
+		Success:   true,
+		Date:      time.Unix(parsed.Timestamp, 0).UTC().Format(dateLayout),
+		Base:      parsed.Base,
+		Timestamp: parsed.Timestamp,
+		Rates:     parsed.Rates,
+	}
+	if err = validateRates(impl.name, rates); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "provider returned invalid rates")
+		return
+	}
+	result = rates
+	return
+}
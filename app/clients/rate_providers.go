@@ -0,0 +1,196 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/go-masonry/mortar/interfaces/cfg"
+	"github.com/go-masonry/mortar/interfaces/http/client"
+	"github.com/go-masonry/mortar/interfaces/log"
+	"go.uber.org/fx"
+)
+
+type (
+	// RateProvider is a single upstream source of exchange rates
+	RateProvider interface {
+		Name() string
+		GetRates(context.Context) (*model.ExchangeRatesModel, error)
+	}
+
+	// HistoricalRateProvider is a provider that can also serve the rates of a past date
+	HistoricalRateProvider interface {
+		RateProvider
+		GetHistoricalRates(ctx context.Context, date time.Time) (*model.ExchangeRatesModel, error)
+	}
+
+	// RateProviders is the registry of the configured providers
+	RateProviders interface {
+		// All returns the providers in their configuration order
+		All() []RateProvider
+		Get(name string) (RateProvider, bool)
+	}
+
+	rateProvidersImplDeps struct {
+		fx.In
+
+		Logger            log.Logger
+		Config            cfg.Config
+		HTTPClientBuilder client.NewHTTPClientBuilder
+	}
+
+	rateProvidersImpl struct {
+		providers []RateProvider
+		byName    map[string]RateProvider
+	}
+
+	providerConfig struct {
+		Name    string        `mapstructure:"name"`
+		Type    string        `mapstructure:"type"`
+		URL     string        `mapstructure:"url"`
+		APIKey  string        `mapstructure:"apiKey"`
+		Timeout time.Duration `mapstructure:"timeout"`
+		// Path of the static file provider
+		Path string `mapstructure:"path"`
+	}
+
+	providerFactory func(deps rateProvidersImplDeps, config providerConfig, httpClient *http.Client) (RateProvider, error)
+)
+
+const (
+	providersKey = "exchangerate.providers"
+
+	defaultProviderTimeout = 30 * time.Second
+	// dateLayout is the format of the dates in the provider responses and endpoints
+	dateLayout = "2006-01-02"
+
+	FixerProvider             = "fixer"
+	ECBProvider               = "ecb"
+	OpenExchangeRatesProvider = "openexchangerates"
+	ExchangeRateHostProvider  = "exchangeratehost"
+	FileProvider              = "file"
+	CoinbaseProvider          = "coinbase"
+)
+
+var providerFactories = map[string]providerFactory{
+	FixerProvider:             createFixerProvider,
+	ECBProvider:               createECBProvider,
+	OpenExchangeRatesProvider: createOpenExchangeRatesProvider,
+	ExchangeRateHostProvider:  createExchangeRateHostProvider,
+	FileProvider:              createFileProvider,
+	CoinbaseProvider:          createCoinbaseProvider,
+}
+
+// CreateRateProviders builds the providers listed under exchangerate.providers, when there are none the legacy
+// exchangerate.exchange fixer configuration is used
+func CreateRateProviders(deps rateProvidersImplDeps) (result RateProviders, err error) {
+	var configs []providerConfig
+	if err = deps.Config.Get(providersKey).Unmarshal(&configs); err != nil {
+		err = fmt.Errorf("invalid %s configuration: %w", providersKey, err)
+		return
+	}
+	if len(configs) == 0 {
+		configs = []providerConfig{legacyFixerConfig(deps.Config)}
+	}
+	impl := &rateProvidersImpl{
+		providers: make([]RateProvider, 0, len(configs)),
+		byName:    make(map[string]RateProvider, len(configs)),
+	}
+	for _, config := range configs {
+		if len(config.Name) == 0 {
+			config.Name = config.Type
+		}
+		if _, exists := impl.byName[config.Name]; exists {
+			err = fmt.Errorf("provider %s is configured more than once", config.Name)
+			return
+		}
+		factory, ok := providerFactories[config.Type]
+		if !ok {
+			err = fmt.Errorf("unknown provider type %q of provider %s", config.Type, config.Name)
+			return
+		}
+		if config.Timeout <= 0 {
+			config.Timeout = defaultProviderTimeout
+		}
+		httpClient := deps.HTTPClientBuilder().WithPreconfiguredClient(&http.Client{Timeout: config.Timeout}).Build()
+		var provider RateProvider
+		if provider, err = factory(deps, config, httpClient); err != nil {
+			err = fmt.Errorf("failed creating provider %s: %w", config.Name, err)
+			return
+		}
+		impl.providers = append(impl.providers, provider)
+		impl.byName[config.Name] = provider
+	}
+	result = impl
+	return
+}
+
+func (impl *rateProvidersImpl) All() []RateProvider {
+	return impl.providers
+}
+
+func (impl *rateProvidersImpl) Get(name string) (provider RateProvider, ok bool) {
+	provider, ok = impl.byName[name]
+	return
+}
+
+func legacyFixerConfig(config cfg.Config) providerConfig {
+	return providerConfig{
+		Name:    FixerProvider,
+		Type:    FixerProvider,
+		URL:     config.Get(exchangeUrlKey).String(),
+		APIKey:  config.Get(exchangeAPIKeyKey).String(),
+		Timeout: config.Get(exchangeTimeoutKey).Duration(),
+	}
+}
+
+// fetch returns the body of a successful GET request, failures are classified as a ProviderError
+func fetch(ctx context.Context, logger log.Logger, httpClient *http.Client, provider, url string) (body []byte, err error) {
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, nil); err != nil {
+		logger.WithError(err).Error(ctx, "failed to create a new request")
+		err = newProviderError(provider, ErrInvalidRequest, err, "invalid request")
+		return
+	}
+
+	var res *http.Response
+	if res, err = httpClient.Do(req); err != nil {
+		logger.WithError(err).Error(ctx, "failed to execute request")
+		err = newProviderError(provider, ErrUpstream, err, "request failed")
+		return
+	}
+
+	defer func() {
+		var closeErr error
+		if closeErr = res.Body.Close(); closeErr != nil {
+			logger.WithError(closeErr).Error(ctx, "failed to close response body")
+		}
+	}()
+	if body, err = ioutil.ReadAll(res.Body); err != nil {
+		logger.WithError(err).Error(ctx, "failed to read response body")
+		err = newProviderError(provider, ErrUpstream, err, "failed reading response body")
+		return
+	}
+	if res.StatusCode != http.StatusOK {
+		err = newProviderError(provider, statusErrorType(res.StatusCode), nil, "unexpected http status code %d", res.StatusCode)
+		logger.WithError(err).WithField("body", string(body)).Error(ctx, "request failed")
+	}
+	return
+}
+
+// validateRates makes sure a successfully parsed response actually has rates
+func validateRates(provider string, rates *model.ExchangeRatesModel) error {
+	if len(rates.Base) == 0 {
+		return newProviderError(provider, ErrMalformedBody, nil, "missing base currency")
+	}
+	if len(rates.Rates) == 0 {
+		return newProviderError(provider, ErrMalformedBody, nil, "missing rates")
+	}
+	if rates.Timestamp <= 0 {
+		return newProviderError(provider, ErrMalformedBody, nil, "missing timestamp")
+	}
+	return nil
+}
@@ -5,8 +5,14 @@ import (
 	"fmt"
 	"github.com/bevgene/go-currency-rate/app/data"
 	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/bevgene/go-currency-rate/app/pricing"
 	"github.com/go-masonry/mortar/interfaces/cfg"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"math"
+	"time"
 
 	currencyconverter "github.com/bevgene/go-currency-rate/api"
 
@@ -22,9 +28,12 @@ type (
 	currencyRateControllerImplDeps struct {
 		fx.In
 
-		Logger          log.Logger
-		Config          cfg.Config
-		CurrencyRateDao data.CurrencyRateDao
+		Logger           log.Logger
+		Config           cfg.Config
+		CurrencyRateDao  data.CurrencyRateDao
+		RatesBroadcaster data.RatesBroadcaster
+		Pricer           pricing.Pricer
+		QuoteDao         data.QuoteDao
 	}
 
 	currencyRateControllerImpl struct {
@@ -33,6 +42,10 @@ type (
 	}
 )
 
+const (
+	maxRateAgeKey = "exchangerate.maxRateAge"
+)
+
 func CreateCurrencyRateController(deps currencyRateControllerImplDeps) CurrencyRateController {
 	return &currencyRateControllerImpl{
 		deps: deps,
@@ -40,41 +53,334 @@ func CreateCurrencyRateController(deps currencyRateControllerImplDeps) CurrencyR
 }
 
 func (impl *currencyRateControllerImpl) Convert(ctx context.Context, request *currencyconverter.ConvertRequest) (result *currencyconverter.ConvertResponse, err error) {
+	var ratesDocument *model.ExchangeRateDocument
+	if ratesDocument, err = getRates(ctx, impl.deps.CurrencyRateDao, request.GetAsOf()); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "failed fetching rates information from db")
+		return
+	}
+	var warning string
+	if warning, err = impl.checkRatesAge(ctx, ratesDocument, request.GetAsOf(), request.GetStrict()); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "convert failed")
+		return
+	}
+	if result, err = impl.convert(ctx, ratesDocument, request); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "convert failed")
+		return
+	}
+	result.Warning = warning
+	impl.deps.Logger.WithField("request", request).WithField("result", result).Info(ctx, "finished conversion")
+	return
+}
+
+func (impl *currencyRateControllerImpl) BatchConvert(ctx context.Context, request *currencyconverter.BatchConvertRequest) (result *currencyconverter.BatchConvertResponse, err error) {
+	var ratesDocument *model.ExchangeRateDocument
+	if ratesDocument, err = getRates(ctx, impl.deps.CurrencyRateDao, request.GetAsOf()); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "failed fetching rates information from db")
+		return
+	}
+	result = &currencyconverter.BatchConvertResponse{
+		Results:         make([]*currencyconverter.BatchConvertResult, 0, len(request.GetItems())),
+		CorrectnessTime: timestamppb.New(ratesDocument.CreatedAt),
+	}
+	for _, item := range request.GetItems() {
+		var itemResult *currencyconverter.ConvertResponse
+		var warning string
+		var itemErr error
+		if warning, itemErr = impl.checkRatesAge(ctx, ratesDocument, request.GetAsOf(), item.GetStrict()); itemErr == nil {
+			itemResult, itemErr = impl.convert(ctx, ratesDocument, item)
+		}
+		if itemErr != nil {
+			impl.deps.Logger.WithError(itemErr).WithField("item", item).Warn(ctx, "batch item convert failed")
+			result.Results = append(result.Results, BatchConvertError(itemErr))
+			continue
+		}
+		itemResult.Warning = warning
+		result.Results = append(result.Results, &currencyconverter.BatchConvertResult{
+			Result: &currencyconverter.BatchConvertResult_Response{Response: itemResult},
+		})
+	}
+	impl.deps.Logger.WithField("items", len(request.GetItems())).Info(ctx, "finished batch conversion")
+	return
+}
+
+// WatchRates sends the latest snapshot and then a new one every time fresh rates are stored, until the client goes away
+func (impl *currencyRateControllerImpl) WatchRates(request *currencyconverter.WatchRatesRequest, stream currencyconverter.CurrencyConverter_WatchRatesServer) (err error) {
+	ctx := stream.Context()
+	updates, unsubscribe := impl.deps.RatesBroadcaster.Subscribe()
+	defer unsubscribe()
+
 	var ratesDocument *model.ExchangeRateDocument
 	if ratesDocument, err = impl.deps.CurrencyRateDao.GetRates(ctx); err != nil {
 		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "failed fetching latest rates information from db")
+		err = &RatesUnavailableError{Reason: ReasonDatabaseUnavailable, Err: err}
 		return
 	}
-	if ratesDocument == nil {
-		err = fmt.Errorf("no information found in db")
-		impl.deps.Logger.WithError(err).Error(ctx, "convert failed")
+	var previousRates map[string]float32
+	for {
+		if ratesDocument != nil {
+			var snapshot *currencyconverter.RatesSnapshot
+			if snapshot, err = ratesSnapshot(ratesDocument, request); err != nil {
+				impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "watch rates failed")
+				return
+			}
+			currentRates := snapshot.Rates
+			if request.GetChangesOnly() && previousRates != nil {
+				snapshot.Rates = changedRates(previousRates, currentRates)
+			}
+			previousRates = currentRates
+			if len(snapshot.Rates) > 0 {
+				if err = stream.Send(snapshot); err != nil {
+					impl.deps.Logger.WithError(err).Warn(ctx, "failed sending rates snapshot")
+					return
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case ratesDocument = <-updates:
+		}
+	}
+}
+
+func (impl *currencyRateControllerImpl) GetRate(ctx context.Context, request *currencyconverter.GetRateRequest) (result *currencyconverter.GetRateResponse, err error) {
+	var ratesDocument *model.ExchangeRateDocument
+	if ratesDocument, err = getRates(ctx, impl.deps.CurrencyRateDao, request.GetAsOf()); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "failed fetching rates information from db")
 		return
 	}
-	currencyFrom := request.GetCurrencyFrom()
-	currencyTo := request.GetCurrencyTo()
-	amount := request.GetAmountFrom()
 	var rateFrom, rateTo float32
-	var ok bool
+	if rateFrom, rateTo, err = lookupRates(ratesDocument, request.GetCurrencyFrom(), request.GetCurrencyTo()); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "get rate failed")
+		return
+	}
+	if rateFrom <= 0 || rateTo <= 0 {
+		err = &RatesUnavailableError{
+			Reason: ReasonIllegalRates,
+			Err:    fmt.Errorf("illegal rates [%f, %f] for %s/%s", rateFrom, rateTo, request.GetCurrencyFrom(), request.GetCurrencyTo()),
+		}
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "get rate failed")
+		return
+	}
+	result = &currencyconverter.GetRateResponse{
+		CurrencyFrom:    request.GetCurrencyFrom(),
+		CurrencyTo:      request.GetCurrencyTo(),
+		Rate:            rateTo / rateFrom,
+		InverseRate:     rateFrom / rateTo,
+		Base:            ratesDocument.Base,
+		CorrectnessTime: timestamppb.New(ratesDocument.CreatedAt),
+	}
+	impl.deps.Logger.WithField("request", request).WithField("result", result).Debug(ctx, "finished get rate")
+	return
+}
 
-	if rateFrom, ok = ratesDocument.Rates[currencyFrom]; !ok {
-		err = fmt.Errorf("unsupported currency %s", currencyFrom)
-		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "convert failed")
+func (impl *currencyRateControllerImpl) ListCurrencies(ctx context.Context, request *currencyconverter.ListCurrenciesRequest) (result *currencyconverter.ListCurrenciesResponse, err error) {
+	var ratesDocument *model.ExchangeRateDocument
+	if ratesDocument, err = getRates(ctx, impl.deps.CurrencyRateDao, nil); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "failed fetching latest rates information from db")
 		return
 	}
-	if rateTo, ok = ratesDocument.Rates[currencyTo]; !ok {
-		err = fmt.Errorf("unsupported currency %s", currencyTo)
-		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "convert failed")
+	codes := supportedCurrencies(ratesDocument)
+	result = &currencyconverter.ListCurrenciesResponse{
+		Currencies:      make([]*currencyconverter.Currency, 0, len(codes)),
+		CorrectnessTime: timestamppb.New(ratesDocument.CreatedAt),
+	}
+	for _, code := range codes {
+		result.Currencies = append(result.Currencies, currencyMetadata(code))
+	}
+	return
+}
+
+// BatchConvertError wraps a single item error as a batch result
+func BatchConvertError(err error) *currencyconverter.BatchConvertResult {
+	return &currencyconverter.BatchConvertResult{
+		Result: &currencyconverter.BatchConvertResult_Error{Error: status.Convert(err).Proto()},
+	}
+}
+
+// convert calculates a single conversion using the provided rates document, applying the client or currency pair pricing
+func (impl *currencyRateControllerImpl) convert(ctx context.Context, ratesDocument *model.ExchangeRateDocument, request *currencyconverter.ConvertRequest) (result *currencyconverter.ConvertResponse, err error) {
+	currencyTo := request.GetCurrencyTo()
+	amount := request.GetAmountFrom()
+	var rateFrom, rateTo float32
+	if rateFrom, rateTo, err = lookupRates(ratesDocument, request.GetCurrencyFrom(), currencyTo); err != nil {
+		return
 	}
 
 	result = &currencyconverter.ConvertResponse{
 		Currency:        currencyTo,
 		Amount:          0,
 		CorrectnessTime: timestamppb.New(ratesDocument.CreatedAt),
+		Stale:           ratesDocument.Stale(),
+		SnapshotAge:     snapshotAge(ratesDocument),
 	}
 
 	if rateFrom > 0 {
 		result.Amount = amount * rateTo / rateFrom
 	}
-	impl.deps.Logger.WithError(err).WithField("request", request).WithField("result", result).Info(ctx, "finished conversion")
+	if math.IsInf(float64(result.Amount), 0) || math.IsNaN(float64(result.Amount)) {
+		// float overflow, there's nothing meaningful to price or round
+		return
+	}
+	breakdown := impl.deps.Pricer.Price(ctx, request.GetClientId(), request.GetCurrencyFrom(), currencyTo, decimal.NewFromFloat32(result.Amount))
+	if breakdown.Priced {
+		result.Amount = float32Of(breakdown.Amount)
+		result.Pricing = pricingBreakdown(breakdown)
+	}
+	if mode := roundingMode(request.GetRoundingMode()); mode != model.RoundingNone {
+		result.Amount = float32Of(model.RoundToMinorUnits(decimal.NewFromFloat32(result.Amount), currencyTo, mode))
+	}
+	return
+}
+
+func pricingBreakdown(breakdown pricing.Breakdown) *currencyconverter.PricingBreakdown {
+	return &currencyconverter.PricingBreakdown{
+		MidMarketAmount: float32Of(breakdown.MidMarketAmount),
+		SpreadAmount:    float32Of(breakdown.SpreadAmount),
+		MarkupAmount:    float32Of(breakdown.MarkupAmount),
+		FeeAmount:       float32Of(breakdown.FeeAmount),
+	}
+}
+
+func float32Of(value decimal.Decimal) float32 {
+	result, _ := value.Float64()
+	return float32(result)
+}
+
+func roundingMode(mode currencyconverter.RoundingMode) model.RoundingMode {
+	switch mode {
+	case currencyconverter.RoundingMode_ROUNDING_MODE_HALF_EVEN:
+		return model.RoundingHalfEven
+	case currencyconverter.RoundingMode_ROUNDING_MODE_HALF_UP:
+		return model.RoundingHalfUp
+	case currencyconverter.RoundingMode_ROUNDING_MODE_DOWN:
+		return model.RoundingDown
+	case currencyconverter.RoundingMode_ROUNDING_MODE_UP:
+		return model.RoundingUp
+	default:
+		return model.RoundingNone
+	}
+}
+
+// lookupRates returns the rates of both currencies relative to the document base
+func lookupRates(ratesDocument *model.ExchangeRateDocument, currencyFrom, currencyTo string) (rateFrom, rateTo float32, err error) {
+	var ok bool
+	if rateFrom, ok = ratesDocument.Rates[currencyFrom]; !ok {
+		err = newUnsupportedCurrencyError(ratesDocument, "currency_from", currencyFrom)
+		return
+	}
+	if rateTo, ok = ratesDocument.Rates[currencyTo]; !ok {
+		err = newUnsupportedCurrencyError(ratesDocument, "currency_to", currencyTo)
+		return
+	}
+	return
+}
+
+// ratesSnapshot expresses the document rates relative to the requested base, keeping only the requested quotes
+func ratesSnapshot(ratesDocument *model.ExchangeRateDocument, request *currencyconverter.WatchRatesRequest) (result *currencyconverter.RatesSnapshot, err error) {
+	base := request.GetBase()
+	if len(base) == 0 {
+		base = ratesDocument.Base
+	}
+	baseRate, ok := ratesDocument.Rates[base]
+	if !ok || baseRate <= 0 {
+		err = newUnsupportedCurrencyError(ratesDocument, "base", base)
+		return
+	}
+	quotes := request.GetQuotes()
+	if len(quotes) == 0 {
+		quotes = make([]string, 0, len(ratesDocument.Rates))
+		for currency := range ratesDocument.Rates {
+			quotes = append(quotes, currency)
+		}
+	}
+	result = &currencyconverter.RatesSnapshot{
+		Base:            base,
+		Rates:           make(map[string]float32, len(quotes)),
+		CorrectnessTime: timestamppb.New(ratesDocument.CreatedAt),
+	}
+	for _, quote := range quotes {
+		var rate float32
+		if rate, ok = ratesDocument.Rates[quote]; !ok {
+			err = newUnsupportedCurrencyError(ratesDocument, "quotes", quote)
+			return
+		}
+		result.Rates[quote] = rate / baseRate
+	}
+	return
+}
+
+func changedRates(previous, current map[string]float32) map[string]float32 {
+	result := make(map[string]float32)
+	for currency, rate := range current {
+		if previousRate, ok := previous[currency]; !ok || previousRate != rate {
+			result[currency] = rate
+		}
+	}
+	return result
+}
+
+func currencyMetadata(code string) *currencyconverter.Currency {
+	result := &currencyconverter.Currency{Code: code}
+	if currency, ok := model.LookupCurrency(code); ok {
+		result.Name = currency.Name
+		result.NumericCode = currency.NumericCode
+		result.Symbol = currency.Symbol
+		if currency.MinorUnits != model.NoMinorUnits {
+			minorUnits := int32(currency.MinorUnits)
+			result.MinorUnits = &minorUnits
+		}
+	}
+	return result
+}
+
+// checkRatesAge compares the latest rates to exchangerate.maxRateAge, older rates fail a strict
+// request with UNAVAILABLE and get a warning otherwise, historical rates are old on purpose and aren't checked.
+func (impl *currencyRateControllerImpl) checkRatesAge(ctx context.Context, ratesDocument *model.ExchangeRateDocument, asOf *timestamppb.Timestamp, strict bool) (warning string, err error) {
+	if asOf != nil {
+		return
+	}
+	return checkRatesAge(ctx, impl.deps, ratesDocument, strict)
+}
+
+func checkRatesAge(ctx context.Context, deps currencyRateControllerImplDeps, ratesDocument *model.ExchangeRateDocument, strict bool) (warning string, err error) {
+	age := time.Since(ratesDocument.CreatedAt)
+	maxAge := deps.Config.Get(maxRateAgeKey).Duration()
+	if maxAge <= 0 || age <= maxAge {
+		return
+	}
+	message := fmt.Sprintf("rates are %s old, older than the maximum rate age of %s", age.Truncate(time.Second), maxAge)
+	if strict {
+		err = &RatesUnavailableError{Reason: ReasonOutdatedRates, Err: fmt.Errorf("%s", message)}
+		return
+	}
+	deps.Logger.WithField("age", age.String()).Warn(ctx, "serving outdated rates")
+	return message, nil
+}
+
+// snapshotAge returns how long ago stale rates were loaded from mongo, nil for fresh ones
+func snapshotAge(ratesDocument *model.ExchangeRateDocument) *durationpb.Duration {
+	if !ratesDocument.Stale() {
+		return nil
+	}
+	return durationpb.New(time.Since(ratesDocument.SnapshotAt))
+}
+
+// getRates returns the latest rates document, or the one that was valid at asOf when it's set. The errors are typed,
+// a missing document is an error as well.
+func getRates(ctx context.Context, dao data.CurrencyRateDao, asOf *timestamppb.Timestamp) (result *model.ExchangeRateDocument, err error) {
+	if asOf != nil {
+		result, err = dao.GetRatesAt(ctx, asOf.AsTime())
+	} else {
+		result, err = dao.GetRates(ctx)
+	}
+	switch {
+	case err != nil:
+		err = &RatesUnavailableError{Reason: ReasonDatabaseUnavailable, Err: err}
+	case result == nil && asOf != nil:
+		err = &RatesNotFoundError{AsOf: asOf.AsTime()}
+	case result == nil:
+		err = &RatesUnavailableError{Reason: ReasonNoRates, Err: fmt.Errorf("no information found in db")}
+	}
 	return
 }
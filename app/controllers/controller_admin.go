@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/bevgene/go-currency-rate/app/temporal"
+	"go.temporal.io/api/serviceerror"
+	sdktemporal "go.temporal.io/sdk/temporal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+
+	currencyconverter "github.com/bevgene/go-currency-rate/api"
+
+	"github.com/go-masonry/mortar/interfaces/log"
+	"go.uber.org/fx"
+)
+
+type (
+	AdminController interface {
+		currencyconverter.CurrencyConverterAdminServer
+	}
+
+	adminControllerImplDeps struct {
+		fx.In
+
+		Logger log.Logger
+		// Temporal dependencies are provided along with the worker, they're missing when the service runs without it
+		RatesRefresher  temporal.RatesRefresher  `optional:"true"`
+		ScheduleManager temporal.ScheduleManager `optional:"true"`
+	}
+
+	adminControllerImpl struct {
+		*currencyconverter.UnimplementedCurrencyConverterAdminServer
+		deps adminControllerImplDeps
+	}
+)
+
+func CreateAdminController(deps adminControllerImplDeps) AdminController {
+	return &adminControllerImpl{
+		deps: deps,
+	}
+}
+
+func (impl *adminControllerImpl) RefreshRates(ctx context.Context, request *currencyconverter.RefreshRatesRequest) (result *currencyconverter.RefreshRatesResponse, err error) {
+	if impl.deps.RatesRefresher == nil {
+		err = status.Errorf(codes.Unavailable, "rates refresh isn't available")
+		impl.deps.Logger.WithError(err).Error(ctx, "refresh failed")
+		return
+	}
+	var assetClass model.AssetClass
+	if assetClass, err = model.ParseAssetClass(request.GetAssetClass()); err != nil {
+		err = status.Errorf(codes.InvalidArgument, "%v", err)
+		return
+	}
+	var refresh temporal.RefreshResult
+	if refresh, err = impl.deps.RatesRefresher.Refresh(ctx, assetClass, request.GetWait()); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "refresh failed")
+		err = workflowError(err)
+		return
+	}
+	result = &currencyconverter.RefreshRatesResponse{
+		WorkflowId: refresh.WorkflowID,
+		RunId:      refresh.RunID,
+	}
+	if !refresh.CreatedAt.IsZero() {
+		result.CreatedAt = timestamppb.New(refresh.CreatedAt)
+	}
+	return
+}
+
+// BackfillRates looks up the gaps per day, providers serve a single rates snapshot per day
+func (impl *adminControllerImpl) BackfillRates(ctx context.Context, request *currencyconverter.BackfillRatesRequest) (result *currencyconverter.BackfillRatesResponse, err error) {
+	if impl.deps.RatesRefresher == nil {
+		err = status.Errorf(codes.Unavailable, "rates backfill isn't available")
+		impl.deps.Logger.WithError(err).Error(ctx, "backfill failed")
+		return
+	}
+	var backfill temporal.RefreshResult
+	if backfill, err = impl.deps.RatesRefresher.Backfill(ctx, model.BackfillRequest{
+		Start:    request.GetStart().AsTime().Truncate(24 * time.Hour),
+		End:      request.GetEnd().AsTime(),
+		Interval: model.IntervalDaily,
+	}); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "backfill failed")
+		if _, ok := err.(*serviceerror.WorkflowExecutionAlreadyStarted); ok {
+			err = status.Errorf(codes.AlreadyExists, "a backfill of the same range is already running")
+			return
+		}
+		err = workflowError(err)
+		return
+	}
+	result = &currencyconverter.BackfillRatesResponse{
+		WorkflowId: backfill.WorkflowID,
+		RunId:      backfill.RunID,
+	}
+	return
+}
+
+func (impl *adminControllerImpl) GetSchedule(ctx context.Context, request *currencyconverter.GetScheduleRequest) (result *currencyconverter.Schedule, err error) {
+	var assetClass model.AssetClass
+	if assetClass, err = impl.scheduleAssetClass(request.GetAssetClass()); err != nil {
+		return
+	}
+	var state temporal.ScheduleState
+	if state, err = impl.deps.ScheduleManager.Get(ctx, assetClass); err != nil {
+		err = scheduleError(err, assetClass)
+		return
+	}
+	result = &currencyconverter.Schedule{
+		AssetClass:   string(state.AssetClass),
+		WorkflowId:   impl.deps.ScheduleManager.WorkflowID(assetClass),
+		CronSchedule: state.CronSchedule,
+		Paused:       state.Paused,
+		LastError:    state.LastError,
+	}
+	if !state.NextRunAt.IsZero() {
+		result.NextRunAt = timestamppb.New(state.NextRunAt)
+	}
+	if !state.LastRunAt.IsZero() {
+		result.LastRunAt = timestamppb.New(state.LastRunAt)
+	}
+	return
+}
+
+func (impl *adminControllerImpl) PauseSchedule(ctx context.Context, request *currencyconverter.ScheduleSignalRequest) (*currencyconverter.ScheduleSignalResponse, error) {
+	return impl.signalSchedule(request.GetAssetClass(), func(assetClass model.AssetClass) error {
+		return impl.deps.ScheduleManager.Pause(ctx, assetClass)
+	})
+}
+
+func (impl *adminControllerImpl) ResumeSchedule(ctx context.Context, request *currencyconverter.ScheduleSignalRequest) (*currencyconverter.ScheduleSignalResponse, error) {
+	return impl.signalSchedule(request.GetAssetClass(), func(assetClass model.AssetClass) error {
+		return impl.deps.ScheduleManager.Resume(ctx, assetClass)
+	})
+}
+
+func (impl *adminControllerImpl) TriggerSchedule(ctx context.Context, request *currencyconverter.ScheduleSignalRequest) (*currencyconverter.ScheduleSignalResponse, error) {
+	return impl.signalSchedule(request.GetAssetClass(), func(assetClass model.AssetClass) error {
+		return impl.deps.ScheduleManager.Trigger(ctx, assetClass)
+	})
+}
+
+func (impl *adminControllerImpl) UpdateSchedule(ctx context.Context, request *currencyconverter.UpdateScheduleRequest) (*currencyconverter.ScheduleSignalResponse, error) {
+	return impl.signalSchedule(request.GetAssetClass(), func(assetClass model.AssetClass) error {
+		return impl.deps.ScheduleManager.Update(ctx, assetClass, request.GetCronSchedule())
+	})
+}
+
+func (impl *adminControllerImpl) signalSchedule(name string, signal func(model.AssetClass) error) (result *currencyconverter.ScheduleSignalResponse, err error) {
+	var assetClass model.AssetClass
+	if assetClass, err = impl.scheduleAssetClass(name); err != nil {
+		return
+	}
+	if err = signal(assetClass); err != nil {
+		err = scheduleError(err, assetClass)
+		return
+	}
+	result = &currencyconverter.ScheduleSignalResponse{
+		WorkflowId: impl.deps.ScheduleManager.WorkflowID(assetClass),
+	}
+	return
+}
+
+func (impl *adminControllerImpl) scheduleAssetClass(name string) (assetClass model.AssetClass, err error) {
+	if impl.deps.ScheduleManager == nil {
+		err = status.Errorf(codes.Unavailable, "schedules aren't available")
+		return
+	}
+	if assetClass, err = model.ParseAssetClass(name); err != nil {
+		err = status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return
+}
+
+// scheduleError maps the Temporal errors of a schedule to gRPC ones
+func scheduleError(err error, assetClass model.AssetClass) error {
+	if _, ok := err.(*serviceerror.NotFound); ok {
+		return status.Errorf(codes.NotFound, "%s schedule isn't running", assetClass)
+	}
+	return workflowError(err)
+}
+
+// workflowError maps the errors of the Temporal client and of a workflow run, so that callers don't get UNKNOWN
+func workflowError(err error) error {
+	var executionErr *sdktemporal.WorkflowExecutionError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Errorf(codes.DeadlineExceeded, "%v", err)
+	case errors.Is(err, context.Canceled):
+		return status.Errorf(codes.Canceled, "%v", err)
+	case errors.As(err, &executionErr):
+		return &RatesUnavailableError{Reason: ReasonWorkflowFailed, Err: err}
+	default:
+		return &RatesUnavailableError{Reason: ReasonWorkflowUnavailable, Err: err}
+	}
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"context"
+
+	currencyconverter "github.com/bevgene/go-currency-rate/api"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (impl *currencyRateControllerImpl) GetRateHistory(ctx context.Context, request *currencyconverter.GetRateHistoryRequest) (result *currencyconverter.GetRateHistoryResponse, err error) {
+	interval := request.GetInterval()
+	if interval == currencyconverter.HistoryInterval_HISTORY_INTERVAL_UNSPECIFIED {
+		interval = currencyconverter.HistoryInterval_HISTORY_INTERVAL_RAW
+	}
+	var points []model.RateHistoryPoint
+	if points, err = impl.deps.CurrencyRateDao.GetRateHistory(ctx, request.GetCurrencyFrom(), request.GetCurrencyTo(),
+		request.GetStart().AsTime(), request.GetEnd().AsTime(), historyInterval(interval)); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "failed fetching rate history from db")
+		err = &RatesUnavailableError{Reason: ReasonDatabaseUnavailable, Err: err}
+		return
+	}
+	result = &currencyconverter.GetRateHistoryResponse{
+		CurrencyFrom: request.GetCurrencyFrom(),
+		CurrencyTo:   request.GetCurrencyTo(),
+		Interval:     interval,
+		Points:       make([]*currencyconverter.RatePoint, 0, len(points)),
+	}
+	for _, point := range points {
+		result.Points = append(result.Points, &currencyconverter.RatePoint{
+			Start:   timestamppb.New(point.Start),
+			Open:    float32(point.Open),
+			High:    float32(point.High),
+			Low:     float32(point.Low),
+			Close:   float32(point.Close),
+			Samples: point.Samples,
+		})
+	}
+	impl.deps.Logger.WithField("request", request).WithField("points", len(points)).Debug(ctx, "finished get rate history")
+	return
+}
+
+func historyInterval(interval currencyconverter.HistoryInterval) model.RateHistoryInterval {
+	switch interval {
+	case currencyconverter.HistoryInterval_HISTORY_INTERVAL_HOURLY:
+		return model.IntervalHourly
+	case currencyconverter.HistoryInterval_HISTORY_INTERVAL_DAILY:
+		return model.IntervalDaily
+	case currencyconverter.HistoryInterval_HISTORY_INTERVAL_WEEKLY:
+		return model.IntervalWeekly
+	default:
+		return model.IntervalRaw
+	}
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	currencyconverter "github.com/bevgene/go-currency-rate/api"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const quoteTTLKey = "exchangerate.quotes.ttl"
+
+// CreateQuote locks the cross rate of the latest rates document, it's honoured by ConvertWithQuote until the quote expires
+func (impl *currencyRateControllerImpl) CreateQuote(ctx context.Context, request *currencyconverter.CreateQuoteRequest) (result *currencyconverter.Quote, err error) {
+	var ratesDocument *model.ExchangeRateDocument
+	if ratesDocument, err = getRates(ctx, impl.deps.CurrencyRateDao, nil); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "failed fetching latest rates information from db")
+		return
+	}
+	var rateFrom, rateTo decimal.Decimal
+	if rateFrom, rateTo, err = lookupDecimalRates(ratesDocument, request.GetCurrencyFrom(), request.GetCurrencyTo()); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "create quote failed")
+		return
+	}
+	now := time.Now().UTC()
+	quote := &model.QuoteDocument{
+		ID:             primitive.NewObjectID().Hex(),
+		CurrencyFrom:   request.GetCurrencyFrom(),
+		CurrencyTo:     request.GetCurrencyTo(),
+		Rate:           model.NewDecimal(rateTo.DivRound(rateFrom, divisionPrecision)),
+		ClientID:       request.GetClientId(),
+		RatesCreatedAt: ratesDocument.CreatedAt,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(impl.deps.Config.Get(quoteTTLKey).Duration()),
+	}
+	if err = impl.deps.QuoteDao.AddQuote(ctx, quote); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "failed storing quote")
+		err = &RatesUnavailableError{Reason: ReasonDatabaseUnavailable, Err: err}
+		return
+	}
+	rate, _ := quote.Rate.Float64()
+	result = &currencyconverter.Quote{
+		QuoteId:         quote.ID,
+		CurrencyFrom:    quote.CurrencyFrom,
+		CurrencyTo:      quote.CurrencyTo,
+		Rate:            float32(rate),
+		ExpiresAt:       timestamppb.New(quote.ExpiresAt),
+		CorrectnessTime: timestamppb.New(quote.RatesCreatedAt),
+	}
+	impl.deps.Logger.WithField("request", request).WithField("result", result).Info(ctx, "created quote")
+	return
+}
+
+func (impl *currencyRateControllerImpl) ConvertWithQuote(ctx context.Context, request *currencyconverter.ConvertWithQuoteRequest) (result *currencyconverter.ConvertResponse, err error) {
+	var quote *model.QuoteDocument
+	if quote, err = impl.deps.QuoteDao.GetQuote(ctx, request.GetQuoteId()); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "failed fetching quote from db")
+		err = &RatesUnavailableError{Reason: ReasonDatabaseUnavailable, Err: err}
+		return
+	}
+	if quote == nil {
+		err = &QuoteNotFoundError{QuoteID: request.GetQuoteId()}
+		impl.deps.Logger.WithError(err).Warn(ctx, "convert with quote failed")
+		return
+	}
+	// mongo removes expired quotes in the background, so a stored quote might have already expired
+	if quote.Expired(time.Now()) {
+		err = &QuoteExpiredError{QuoteID: quote.ID, ExpiresAt: quote.ExpiresAt}
+		impl.deps.Logger.WithError(err).Warn(ctx, "convert with quote failed")
+		return
+	}
+	converted := decimal.NewFromFloat32(request.GetAmountFrom()).Mul(quote.Rate.Decimal)
+	breakdown := impl.deps.Pricer.Price(ctx, quote.ClientID, quote.CurrencyFrom, quote.CurrencyTo, converted)
+	result = &currencyconverter.ConvertResponse{
+		Currency:        quote.CurrencyTo,
+		Amount:          float32Of(model.RoundToMinorUnits(breakdown.Amount, quote.CurrencyTo, roundingMode(request.GetRoundingMode()))),
+		CorrectnessTime: timestamppb.New(quote.RatesCreatedAt),
+	}
+	if breakdown.Priced {
+		result.Pricing = pricingBreakdown(breakdown)
+	}
+	impl.deps.Logger.WithField("request", request).WithField("result", result).Info(ctx, "finished conversion with quote")
+	return
+}
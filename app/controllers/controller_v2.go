@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	currencyconverterv2 "github.com/bevgene/go-currency-rate/api/v2"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type (
+	CurrencyRateControllerV2 interface {
+		currencyconverterv2.CurrencyConverterServer
+	}
+
+	currencyRateControllerV2Impl struct {
+		*currencyconverterv2.UnimplementedCurrencyConverterServer
+		deps currencyRateControllerImplDeps
+	}
+)
+
+// divisionPrecision is the number of decimal places kept when dividing rates
+const divisionPrecision int32 = 18
+
+func CreateCurrencyRateControllerV2(deps currencyRateControllerImplDeps) CurrencyRateControllerV2 {
+	return &currencyRateControllerV2Impl{
+		deps: deps,
+	}
+}
+
+func (impl *currencyRateControllerV2Impl) Convert(ctx context.Context, request *currencyconverterv2.ConvertRequest) (result *currencyconverterv2.ConvertResponse, err error) {
+	var amount decimal.Decimal
+	if amount, err = decimal.NewFromString(request.GetAmountFrom()); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "invalid amount")
+		err = &InvalidArgumentError{Field: "amount_from", Err: err}
+		return
+	}
+	var ratesDocument *model.ExchangeRateDocument
+	if ratesDocument, err = getRates(ctx, impl.deps.CurrencyRateDao, request.GetAsOf()); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "failed fetching rates information from db")
+		return
+	}
+	var warning string
+	if request.GetAsOf() == nil {
+		if warning, err = checkRatesAge(ctx, impl.deps, ratesDocument, request.GetStrict()); err != nil {
+			impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "convert failed")
+			return
+		}
+	}
+	var rateFrom, rateTo decimal.Decimal
+	if rateFrom, rateTo, err = lookupDecimalRates(ratesDocument, request.GetCurrencyFrom(), request.GetCurrencyTo()); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "convert failed")
+		return
+	}
+	converted := amount.Mul(rateTo).DivRound(rateFrom, divisionPrecision)
+	breakdown := impl.deps.Pricer.Price(ctx, request.GetClientId(), request.GetCurrencyFrom(), request.GetCurrencyTo(), converted)
+	result = &currencyconverterv2.ConvertResponse{
+		Currency:        request.GetCurrencyTo(),
+		Amount:          model.RoundToMinorUnits(breakdown.Amount, request.GetCurrencyTo(), roundingModeV2(request.GetRoundingMode())).String(),
+		CorrectnessTime: timestamppb.New(ratesDocument.CreatedAt),
+		Stale:           ratesDocument.Stale(),
+		SnapshotAge:     snapshotAge(ratesDocument),
+		Warning:         warning,
+	}
+	if breakdown.Priced {
+		result.Pricing = &currencyconverterv2.PricingBreakdown{
+			MidMarketAmount: breakdown.MidMarketAmount.String(),
+			SpreadAmount:    breakdown.SpreadAmount.String(),
+			MarkupAmount:    breakdown.MarkupAmount.String(),
+			FeeAmount:       breakdown.FeeAmount.String(),
+		}
+	}
+	impl.deps.Logger.WithField("request", request).WithField("result", result).Info(ctx, "finished conversion")
+	return
+}
+
+func (impl *currencyRateControllerV2Impl) GetRate(ctx context.Context, request *currencyconverterv2.GetRateRequest) (result *currencyconverterv2.GetRateResponse, err error) {
+	var ratesDocument *model.ExchangeRateDocument
+	if ratesDocument, err = getRates(ctx, impl.deps.CurrencyRateDao, request.GetAsOf()); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "failed fetching rates information from db")
+		return
+	}
+	var rateFrom, rateTo decimal.Decimal
+	if rateFrom, rateTo, err = lookupDecimalRates(ratesDocument, request.GetCurrencyFrom(), request.GetCurrencyTo()); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "get rate failed")
+		return
+	}
+	result = &currencyconverterv2.GetRateResponse{
+		CurrencyFrom:    request.GetCurrencyFrom(),
+		CurrencyTo:      request.GetCurrencyTo(),
+		Rate:            rateTo.DivRound(rateFrom, divisionPrecision).String(),
+		InverseRate:     rateFrom.DivRound(rateTo, divisionPrecision).String(),
+		Base:            ratesDocument.Base,
+		CorrectnessTime: timestamppb.New(ratesDocument.CreatedAt),
+	}
+	impl.deps.Logger.WithField("request", request).WithField("result", result).Debug(ctx, "finished get rate")
+	return
+}
+
+func roundingModeV2(mode currencyconverterv2.RoundingMode) model.RoundingMode {
+	switch mode {
+	case currencyconverterv2.RoundingMode_ROUNDING_MODE_HALF_EVEN:
+		return model.RoundingHalfEven
+	case currencyconverterv2.RoundingMode_ROUNDING_MODE_HALF_UP:
+		return model.RoundingHalfUp
+	case currencyconverterv2.RoundingMode_ROUNDING_MODE_DOWN:
+		return model.RoundingDown
+	case currencyconverterv2.RoundingMode_ROUNDING_MODE_UP:
+		return model.RoundingUp
+	default:
+		return model.RoundingNone
+	}
+}
+
+// lookupDecimalRates returns the exact rates of both currencies relative to the document base
+func lookupDecimalRates(ratesDocument *model.ExchangeRateDocument, currencyFrom, currencyTo string) (rateFrom, rateTo decimal.Decimal, err error) {
+	var from, to model.Decimal
+	var ok bool
+	if from, ok = ratesDocument.DecimalRate(currencyFrom); !ok {
+		err = newUnsupportedCurrencyError(ratesDocument, "currency_from", currencyFrom)
+		return
+	}
+	if to, ok = ratesDocument.DecimalRate(currencyTo); !ok {
+		err = newUnsupportedCurrencyError(ratesDocument, "currency_to", currencyTo)
+		return
+	}
+	if !from.IsPositive() || !to.IsPositive() {
+		err = &RatesUnavailableError{
+			Reason: ReasonIllegalRates,
+			Err:    fmt.Errorf("illegal rates [%s, %s] for %s/%s", from, to, currencyFrom, currencyTo),
+		}
+		return
+	}
+	rateFrom, rateTo = from.Decimal, to.Decimal
+	return
+}
@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+// The errors below implement GRPCStatus, gRPC clients get their code and details and the gateway maps them to the
+// matching HTTP status with a JSON body
+type (
+	// UnsupportedCurrencyError is returned for a currency that's missing from the rates, it maps to NOT_FOUND
+	UnsupportedCurrencyError struct {
+		// Field of the request holding the currency
+		Field     string
+		Currency  string
+		Supported []string
+	}
+
+	// InvalidArgumentError maps to INVALID_ARGUMENT
+	InvalidArgumentError struct {
+		Field string
+		Err   error
+	}
+
+	// RatesNotFoundError is returned when there are no rates at the requested point in time, it maps to NOT_FOUND
+	RatesNotFoundError struct {
+		AsOf time.Time
+	}
+
+	// QuoteNotFoundError is returned for a quote that was never created or was already removed, it maps to NOT_FOUND
+	QuoteNotFoundError struct {
+		QuoteID string
+	}
+
+	// QuoteExpiredError is returned for a quote that's past its expiry, it maps to FAILED_PRECONDITION
+	QuoteExpiredError struct {
+		QuoteID   string
+		ExpiresAt time.Time
+	}
+
+	// RatesUnavailableError is returned when there are no usable rates to serve, it maps to UNAVAILABLE
+	RatesUnavailableError struct {
+		// Reason is one of the Reason* constants
+		Reason string
+		Err    error
+	}
+)
+
+const (
+	// errorDomain is the domain of the ErrorInfo details
+	errorDomain = "exchangerate"
+
+	ReasonUnsupportedCurrency = "UNSUPPORTED_CURRENCY"
+	ReasonInvalidArgument     = "INVALID_ARGUMENT"
+	ReasonRatesNotFound       = "RATES_NOT_FOUND"
+	ReasonQuoteNotFound       = "QUOTE_NOT_FOUND"
+	ReasonQuoteExpired        = "QUOTE_EXPIRED"
+	// ReasonDatabaseUnavailable means the rates couldn't be loaded
+	ReasonDatabaseUnavailable = "DATABASE_UNAVAILABLE"
+	// ReasonNoRates means no rates were stored yet
+	ReasonNoRates = "NO_RATES"
+	// ReasonIllegalRates means the stored rates of the requested currencies aren't positive
+	ReasonIllegalRates = "ILLEGAL_RATES"
+	// ReasonOutdatedRates means the latest rates are older than exchangerate.maxRateAge
+	ReasonOutdatedRates = "OUTDATED_RATES"
+	// ReasonWorkflowUnavailable means Temporal couldn't be reached to start or signal a workflow
+	ReasonWorkflowUnavailable = "WORKFLOW_UNAVAILABLE"
+	// ReasonWorkflowFailed means the rates workflow ran but failed, e.g. the providers failed
+	ReasonWorkflowFailed = "WORKFLOW_FAILED"
+)
+
+func newUnsupportedCurrencyError(ratesDocument *model.ExchangeRateDocument, field, currency string) *UnsupportedCurrencyError {
+	return &UnsupportedCurrencyError{
+		Field:     field,
+		Currency:  currency,
+		Supported: supportedCurrencies(ratesDocument),
+	}
+}
+
+func (err *UnsupportedCurrencyError) Error() string {
+	return fmt.Sprintf("unsupported currency %s", err.Currency)
+}
+
+func (err *UnsupportedCurrencyError) GRPCStatus() *status.Status {
+	return statusWithDetails(codes.NotFound, err.Error(),
+		errorInfo(ReasonUnsupportedCurrency, map[string]string{
+			"field":     err.Field,
+			"currency":  err.Currency,
+			"supported": strings.Join(err.Supported, ","),
+		}),
+		badRequest(err.Field, err.Error()),
+	)
+}
+
+func (err *InvalidArgumentError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", err.Field, err.Err)
+}
+
+func (err *InvalidArgumentError) Unwrap() error {
+	return err.Err
+}
+
+func (err *InvalidArgumentError) GRPCStatus() *status.Status {
+	return statusWithDetails(codes.InvalidArgument, err.Error(),
+		errorInfo(ReasonInvalidArgument, map[string]string{"field": err.Field}),
+		badRequest(err.Field, err.Err.Error()),
+	)
+}
+
+func (err *RatesNotFoundError) Error() string {
+	return fmt.Sprintf("no rates at %s", err.AsOf.Format(time.RFC3339))
+}
+
+func (err *RatesNotFoundError) GRPCStatus() *status.Status {
+	return statusWithDetails(codes.NotFound, err.Error(),
+		errorInfo(ReasonRatesNotFound, map[string]string{"as_of": err.AsOf.Format(time.RFC3339)}),
+		badRequest("as_of", "there are no rates at or before this time"),
+	)
+}
+
+func (err *QuoteNotFoundError) Error() string {
+	return fmt.Sprintf("quote %s not found", err.QuoteID)
+}
+
+func (err *QuoteNotFoundError) GRPCStatus() *status.Status {
+	return statusWithDetails(codes.NotFound, err.Error(),
+		errorInfo(ReasonQuoteNotFound, map[string]string{"quote_id": err.QuoteID}),
+		badRequest("quote_id", err.Error()),
+	)
+}
+
+func (err *QuoteExpiredError) Error() string {
+	return fmt.Sprintf("quote %s expired at %s", err.QuoteID, err.ExpiresAt.Format(time.RFC3339))
+}
+
+func (err *QuoteExpiredError) GRPCStatus() *status.Status {
+	return statusWithDetails(codes.FailedPrecondition, err.Error(),
+		errorInfo(ReasonQuoteExpired, map[string]string{
+			"quote_id":   err.QuoteID,
+			"expires_at": err.ExpiresAt.Format(time.RFC3339),
+		}),
+		badRequest("quote_id", err.Error()),
+	)
+}
+
+func (err *RatesUnavailableError) Error() string {
+	return fmt.Sprintf("rates are unavailable: %v", err.Err)
+}
+
+func (err *RatesUnavailableError) Unwrap() error {
+	return err.Err
+}
+
+func (err *RatesUnavailableError) GRPCStatus() *status.Status {
+	return statusWithDetails(codes.Unavailable, err.Error(), errorInfo(err.Reason, nil))
+}
+
+func errorInfo(reason string, metadata map[string]string) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: metadata,
+	}
+}
+
+func badRequest(field, description string) *errdetails.BadRequest {
+	return &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+			Field:       field,
+			Description: description,
+		}},
+	}
+}
+
+// statusWithDetails falls back to a status without details, details are a courtesy to the client
+func statusWithDetails(code codes.Code, message string, details ...protoiface.MessageV1) *status.Status {
+	result := status.New(code, message)
+	if detailed, err := result.WithDetails(details...); err == nil {
+		return detailed
+	}
+	return result
+}
+
+// supportedCurrencies returns the sorted currency codes of the rates
+func supportedCurrencies(ratesDocument *model.ExchangeRateDocument) []string {
+	result := make([]string, 0, len(ratesDocument.Rates))
+	for code := range ratesDocument.Rates {
+		result = append(result, code)
+	}
+	sort.Strings(result)
+	return result
+}
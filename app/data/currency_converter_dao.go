@@ -6,35 +6,115 @@ import (
 	"github.com/bevgene/go-currency-rate/app/model"
 	"github.com/go-masonry/mortar/interfaces/cfg"
 	"github.com/go-masonry/mortar/interfaces/log"
+	"github.com/go-masonry/mortar/interfaces/monitor"
 	"go.uber.org/fx"
+	"time"
 )
 
 type (
 	CurrencyRateDao interface {
 		GetRates(ctx context.Context) (*model.ExchangeRateDocument, error)
+		GetRatesAt(ctx context.Context, at time.Time) (*model.ExchangeRateDocument, error)
+		GetRateHistory(ctx context.Context, currencyFrom, currencyTo string, start, end time.Time, interval model.RateHistoryInterval) ([]model.RateHistoryPoint, error)
+		// MergeAssetClasses overlays a fiat document with the latest rates of the asset classes fetched separately
+		MergeAssetClasses(ctx context.Context, fiat *model.ExchangeRateDocument) (*model.ExchangeRateDocument, error)
 	}
 
 	currencyRateDaoImplDeps struct {
 		fx.In
 
-		Logger          log.Logger
-		Config          cfg.Config
-		Lifecycle       fx.Lifecycle
-		LazyMongoClient *clients.LazyMongoClient
+		Logger           log.Logger
+		Config           cfg.Config
+		Lifecycle        fx.Lifecycle
+		LazyMongoClient  *clients.LazyMongoClient
+		RatesBroadcaster RatesBroadcaster
+		Metrics          monitor.Metrics `optional:"true"`
 	}
 
 	currencyRateDaoImpl struct {
-		deps currencyRateDaoImplDeps
+		deps         currencyRateDaoImplDeps
+		assetClasses []clients.AssetClassConfig
 	}
 )
 
-func CreateCurrencyRateDao(deps currencyRateDaoImplDeps) (result CurrencyRateDao) {
+func CreateCurrencyRateDao(deps currencyRateDaoImplDeps) (result CurrencyRateDao, err error) {
+	var assetClasses []clients.AssetClassConfig
+	if assetClasses, err = clients.ReadAssetClasses(deps.Config); err != nil {
+		return
+	}
 	result = &currencyRateDaoImpl{
-		deps: deps,
+		deps:         deps,
+		assetClasses: assetClasses,
+	}
+	if deps.Config.Get(watchEnabledKey).Bool() {
+		if err = startRatesWatcher(deps, result); err != nil {
+			return
+		}
+	}
+	if path := deps.Config.Get(fallbackPathKey).String(); len(path) > 0 {
+		result = newRatesFallback(deps, result, path)
+	}
+	// the cache serves the stale rates of the fallback until mongo is back
+	if deps.Config.Get(cacheEnabledKey).Bool() {
+		var cache *ratesCacheImpl
+		if cache, err = newRatesCache(deps, result); err != nil {
+			return
+		}
+		result = cache
+	}
+	if deps.Metrics != nil {
+		err = startRatesAgeReporter(deps, result)
+	}
+	return
+}
+
+func (impl *currencyRateDaoImpl) GetRates(ctx context.Context) (result *model.ExchangeRateDocument, err error) {
+	if result, err = impl.deps.LazyMongoClient.Client.GetLatestRateDocument(ctx); err != nil {
+		return
+	}
+	return impl.MergeAssetClasses(ctx, result)
+}
+
+func (impl *currencyRateDaoImpl) GetRatesAt(ctx context.Context, at time.Time) (result *model.ExchangeRateDocument, err error) {
+	if result, err = impl.deps.LazyMongoClient.Client.GetRateDocumentAt(ctx, at); err != nil || result == nil {
+		return
+	}
+	return impl.mergeAssetClasses(ctx, result, func(class model.AssetClass) (*model.ExchangeRateDocument, error) {
+		return impl.deps.LazyMongoClient.Client.GetAssetClassRateDocumentAt(ctx, class, at)
+	})
+}
+
+func (impl *currencyRateDaoImpl) MergeAssetClasses(ctx context.Context, fiat *model.ExchangeRateDocument) (*model.ExchangeRateDocument, error) {
+	return impl.mergeAssetClasses(ctx, fiat, func(class model.AssetClass) (*model.ExchangeRateDocument, error) {
+		return impl.deps.LazyMongoClient.Client.GetLatestAssetClassRateDocument(ctx, class)
+	})
+}
+
+// mergeAssetClasses overlays the fiat rates with the document of every configured asset class, a class that can't be
+// merged is left out rather than failing the fiat conversions
+func (impl *currencyRateDaoImpl) mergeAssetClasses(ctx context.Context, fiat *model.ExchangeRateDocument, classDocument func(model.AssetClass) (*model.ExchangeRateDocument, error)) (result *model.ExchangeRateDocument, err error) {
+	result = fiat
+	if fiat == nil {
+		return
+	}
+	for _, assetClass := range impl.assetClasses {
+		var document *model.ExchangeRateDocument
+		if document, err = classDocument(assetClass.Class); err != nil {
+			return
+		}
+		if document == nil {
+			continue
+		}
+		merged, mergeErr := result.Merge(document)
+		if mergeErr != nil {
+			impl.deps.Logger.WithError(mergeErr).Warn(ctx, "asset class rates left out")
+			continue
+		}
+		result = merged
 	}
 	return
 }
 
-func (impl *currencyRateDaoImpl) GetRates(ctx context.Context) (*model.ExchangeRateDocument, error) {
-	return impl.deps.LazyMongoClient.Client.GetLatestRateDocument(ctx)
+func (impl *currencyRateDaoImpl) GetRateHistory(ctx context.Context, currencyFrom, currencyTo string, start, end time.Time, interval model.RateHistoryInterval) ([]model.RateHistoryPoint, error) {
+	return impl.deps.LazyMongoClient.Client.GetRateHistory(ctx, currencyFrom, currencyTo, start, end, interval)
 }
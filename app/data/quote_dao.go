@@ -0,0 +1,43 @@
+package data
+
+import (
+	"context"
+
+	"github.com/bevgene/go-currency-rate/app/clients"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/go-masonry/mortar/interfaces/log"
+	"go.uber.org/fx"
+)
+
+type (
+	QuoteDao interface {
+		AddQuote(ctx context.Context, quote *model.QuoteDocument) error
+		// GetQuote returns nil when the quote doesn't exist, expired quotes are returned until mongo removes them after exchangerate.quotes.retention
+		GetQuote(ctx context.Context, id string) (*model.QuoteDocument, error)
+	}
+
+	quoteDaoImplDeps struct {
+		fx.In
+
+		Logger          log.Logger
+		LazyMongoClient *clients.LazyMongoClient
+	}
+
+	quoteDaoImpl struct {
+		deps quoteDaoImplDeps
+	}
+)
+
+func CreateQuoteDao(deps quoteDaoImplDeps) QuoteDao {
+	return &quoteDaoImpl{
+		deps: deps,
+	}
+}
+
+func (impl *quoteDaoImpl) AddQuote(ctx context.Context, quote *model.QuoteDocument) error {
+	return impl.deps.LazyMongoClient.Client.AddQuoteDocument(ctx, quote)
+}
+
+func (impl *quoteDaoImpl) GetQuote(ctx context.Context, id string) (*model.QuoteDocument, error) {
+	return impl.deps.LazyMongoClient.Client.GetQuoteDocument(ctx, id)
+}
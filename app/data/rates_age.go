@@ -0,0 +1,88 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+	"go.uber.org/fx"
+)
+
+const (
+	ratesAgeIntervalKey = "exchangerate.ratesAgeInterval"
+	ratesAgeGauge       = "rates_age_seconds"
+)
+
+// ratesAgeReporterImpl reports the age of the latest rates on a ticker, so that the gauge keeps growing while no rates
+// are stored whether or not conversions are requested
+type ratesAgeReporterImpl struct {
+	deps     currencyRateDaoImplDeps
+	dao      CurrencyRateDao
+	interval time.Duration
+	// latest is the last document read, its age is still reported while the dao fails
+	latest *model.ExchangeRateDocument
+}
+
+// startRatesAgeReporter reads the given dao, it should be the cached one so that reporting doesn't query mongo
+func startRatesAgeReporter(deps currencyRateDaoImplDeps, dao CurrencyRateDao) (err error) {
+	impl := &ratesAgeReporterImpl{
+		deps:     deps,
+		dao:      dao,
+		interval: deps.Config.Get(ratesAgeIntervalKey).Duration(),
+	}
+	if impl.interval <= 0 {
+		err = fmt.Errorf("%s must be positive, got %s", ratesAgeIntervalKey, impl.interval)
+		return
+	}
+	var stop chan struct{}
+	var done chan struct{}
+	deps.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			stop = make(chan struct{})
+			done = make(chan struct{})
+			go impl.reportLoop(stop, done)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			close(stop)
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+	return
+}
+
+func (impl *ratesAgeReporterImpl) reportLoop(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(impl.interval)
+	defer ticker.Stop()
+	impl.report(context.Background())
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			impl.report(context.Background())
+		}
+	}
+}
+
+func (impl *ratesAgeReporterImpl) report(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, impl.interval)
+	defer cancel()
+	document, err := impl.dao.GetRates(ctx)
+	if err != nil {
+		impl.deps.Logger.WithError(err).Debug(ctx, "failed reading the latest rates for their age")
+	} else if document != nil {
+		impl.latest = document
+	}
+	if impl.latest == nil {
+		return
+	}
+	age := time.Since(impl.latest.CreatedAt)
+	impl.deps.Metrics.Gauge(ratesAgeGauge, "age of the latest rates snapshot in seconds").Set(age.Seconds())
+}
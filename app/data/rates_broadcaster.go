@@ -0,0 +1,80 @@
+package data
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/go-masonry/mortar/interfaces/log"
+	"go.uber.org/fx"
+)
+
+type (
+	// RatesBroadcaster fans out newly stored rates documents to in-process subscribers.
+	//
+	// The instance that executed the Temporal activity publishes the stored rates right away, every instance publishes
+	// them once its rates watcher polls them from mongo.
+	RatesBroadcaster interface {
+		Publish(ctx context.Context, document *model.ExchangeRateDocument)
+		// Subscribe returns a channel with the latest published documents and a function to cancel the subscription
+		Subscribe() (<-chan *model.ExchangeRateDocument, func())
+	}
+
+	ratesBroadcasterImplDeps struct {
+		fx.In
+
+		Logger log.Logger
+	}
+
+	ratesBroadcasterImpl struct {
+		deps        ratesBroadcasterImplDeps
+		lock        sync.Mutex
+		subscribers map[chan *model.ExchangeRateDocument]struct{}
+		// latest is the last published document
+		latest *model.ExchangeRateDocument
+	}
+)
+
+func CreateRatesBroadcaster(deps ratesBroadcasterImplDeps) RatesBroadcaster {
+	return &ratesBroadcasterImpl{
+		deps:        deps,
+		subscribers: make(map[chan *model.ExchangeRateDocument]struct{}),
+	}
+}
+
+// Publish never blocks, a subscriber that didn't consume the previous document gets it replaced by the newer one.
+// Documents older than the last published one, or equal to it, are dropped.
+func (impl *ratesBroadcasterImpl) Publish(ctx context.Context, document *model.ExchangeRateDocument) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	if latest := impl.latest; latest != nil {
+		older := document.CreatedAt.Before(latest.CreatedAt)
+		// rates of another asset class are merged without changing the creation time of the fiat rates
+		published := document.CreatedAt.Equal(latest.CreatedAt) && reflect.DeepEqual(document.Rates, latest.Rates)
+		if older || published {
+			return
+		}
+	}
+	impl.latest = document
+	for subscriber := range impl.subscribers {
+		select {
+		case <-subscriber:
+		default:
+		}
+		subscriber <- document
+	}
+	impl.deps.Logger.WithField("subscribers", len(impl.subscribers)).Debug(ctx, "published rates document")
+}
+
+func (impl *ratesBroadcasterImpl) Subscribe() (<-chan *model.ExchangeRateDocument, func()) {
+	subscriber := make(chan *model.ExchangeRateDocument, 1)
+	impl.lock.Lock()
+	impl.subscribers[subscriber] = struct{}{}
+	impl.lock.Unlock()
+	return subscriber, func() {
+		impl.lock.Lock()
+		delete(impl.subscribers, subscriber)
+		impl.lock.Unlock()
+	}
+}
@@ -0,0 +1,147 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+	"go.uber.org/fx"
+)
+
+const (
+	cacheEnabledKey         = "exchangerate.cache.enabled"
+	cacheRefreshIntervalKey = "exchangerate.cache.refreshInterval"
+	cacheMaxStalenessKey    = "exchangerate.cache.maxStaleness"
+)
+
+// ratesCacheImpl keeps the latest rates document in memory, it's refreshed whenever rates are published and by polling
+// mongo unless the rates watcher polls it already. Historical rates and the rate history aren't cached.
+type ratesCacheImpl struct {
+	CurrencyRateDao
+	deps            currencyRateDaoImplDeps
+	refreshInterval time.Duration
+	maxStaleness    time.Duration
+	// polling is off while the rates watcher publishes the stored rates, the cache is read through once they're stale
+	polling bool
+
+	lock        sync.RWMutex
+	document    *model.ExchangeRateDocument
+	refreshedAt time.Time
+	// loadLock makes concurrent cache misses wait for a single mongo query
+	loadLock sync.Mutex
+}
+
+func newRatesCache(deps currencyRateDaoImplDeps, dao CurrencyRateDao) (result *ratesCacheImpl, err error) {
+	impl := &ratesCacheImpl{
+		CurrencyRateDao: dao,
+		deps:            deps,
+		refreshInterval: deps.Config.Get(cacheRefreshIntervalKey).Duration(),
+		maxStaleness:    deps.Config.Get(cacheMaxStalenessKey).Duration(),
+		polling:         !deps.Config.Get(watchEnabledKey).Bool(),
+	}
+	if impl.refreshInterval <= 0 {
+		err = fmt.Errorf("%s must be positive, got %s", cacheRefreshIntervalKey, impl.refreshInterval)
+		return
+	}
+	if impl.maxStaleness <= 0 {
+		err = fmt.Errorf("%s must be positive, got %s", cacheMaxStalenessKey, impl.maxStaleness)
+		return
+	}
+	var stop chan struct{}
+	var done chan struct{}
+	deps.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			stop = make(chan struct{})
+			done = make(chan struct{})
+			go impl.refreshLoop(stop, done)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			close(stop)
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+	result = impl
+	return
+}
+
+// GetRates serves the cached document as long as it was refreshed within maxStaleness, once it's older mongo is queried
+// and its error is returned
+func (impl *ratesCacheImpl) GetRates(ctx context.Context) (result *model.ExchangeRateDocument, err error) {
+	var ok bool
+	if result, ok = impl.cached(); ok {
+		return
+	}
+	impl.loadLock.Lock()
+	defer impl.loadLock.Unlock()
+	if result, ok = impl.cached(); ok {
+		return
+	}
+	if result, err = impl.CurrencyRateDao.GetRates(ctx); err != nil {
+		return
+	}
+	impl.store(result)
+	return
+}
+
+func (impl *ratesCacheImpl) cached() (*model.ExchangeRateDocument, bool) {
+	impl.lock.RLock()
+	defer impl.lock.RUnlock()
+	if impl.refreshedAt.IsZero() || time.Since(impl.refreshedAt) > impl.maxStaleness {
+		return nil, false
+	}
+	return impl.document, true
+}
+
+// store replaces the cached document unless it's older, a poll that raced a publish mustn't revert it
+func (impl *ratesCacheImpl) store(document *model.ExchangeRateDocument) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	if document != nil && impl.document != nil && document.CreatedAt.Before(impl.document.CreatedAt) {
+		return
+	}
+	impl.document = document
+	impl.refreshedAt = time.Now()
+}
+
+func (impl *ratesCacheImpl) refreshLoop(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	updates, unsubscribe := impl.deps.RatesBroadcaster.Subscribe()
+	defer unsubscribe()
+	var ticks <-chan time.Time
+	if impl.polling {
+		ticker := time.NewTicker(impl.refreshInterval)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+	ctx := context.Background()
+	impl.refresh(ctx)
+	for {
+		select {
+		case <-stop:
+			return
+		case document := <-updates:
+			impl.store(document)
+		case <-ticks:
+			impl.refresh(ctx)
+		}
+	}
+}
+
+// refresh keeps serving the cached document when mongo can't be reached, until it's older than maxStaleness
+func (impl *ratesCacheImpl) refresh(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, impl.refreshInterval)
+	defer cancel()
+	document, err := impl.CurrencyRateDao.GetRates(ctx)
+	if err != nil {
+		impl.deps.Logger.WithError(err).Warn(ctx, "failed refreshing cached rates")
+		return
+	}
+	impl.store(document)
+}
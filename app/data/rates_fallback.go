@@ -0,0 +1,138 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+)
+
+const (
+	fallbackPathKey         = "exchangerate.fallback.path"
+	fallbackMaxStalenessKey = "exchangerate.fallback.maxStaleness"
+
+	// snapshotWriteInterval bounds the writes of an unchanged snapshot, the snapshot age read after a restart is off by
+	// up to this interval
+	snapshotWriteInterval = time.Minute
+)
+
+// ratesFallbackImpl persists the latest rates loaded from mongo to a local file, they're served as stale rates while
+// mongo can't be reached, even across restarts
+type ratesFallbackImpl struct {
+	CurrencyRateDao
+	deps         currencyRateDaoImplDeps
+	path         string
+	maxStaleness time.Duration
+
+	lock     sync.Mutex
+	snapshot *ratesSnapshot
+	// writtenAt is when the snapshot was last written to the file
+	writtenAt time.Time
+}
+
+// ratesSnapshot is the content of the snapshot file
+type ratesSnapshot struct {
+	// LoadedAt is when the document was last loaded from mongo
+	LoadedAt time.Time                   `json:"loaded_at"`
+	Document *model.ExchangeRateDocument `json:"document"`
+}
+
+func newRatesFallback(deps currencyRateDaoImplDeps, dao CurrencyRateDao, path string) *ratesFallbackImpl {
+	return &ratesFallbackImpl{
+		CurrencyRateDao: dao,
+		deps:            deps,
+		path:            path,
+		maxStaleness:    deps.Config.Get(fallbackMaxStalenessKey).Duration(),
+	}
+}
+
+// GetRates serves the snapshot when mongo fails, once the snapshot is older than maxStaleness the mongo error is returned
+func (impl *ratesFallbackImpl) GetRates(ctx context.Context) (result *model.ExchangeRateDocument, err error) {
+	if result, err = impl.CurrencyRateDao.GetRates(ctx); err == nil {
+		if result != nil {
+			impl.save(ctx, result)
+		}
+		return
+	}
+	snapshot := impl.load(ctx)
+	if snapshot == nil || time.Since(snapshot.LoadedAt) > impl.maxStaleness {
+		return
+	}
+	stale := *snapshot.Document
+	stale.SnapshotAt = snapshot.LoadedAt
+	impl.deps.Logger.WithError(err).WithField("snapshot age", time.Since(snapshot.LoadedAt).String()).Warn(ctx, "serving last-known-good rates")
+	return &stale, nil
+}
+
+func (impl *ratesFallbackImpl) save(ctx context.Context, document *model.ExchangeRateDocument) {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	now := time.Now()
+	changed := impl.snapshot == nil || !impl.snapshot.Document.CreatedAt.Equal(document.CreatedAt)
+	impl.snapshot = &ratesSnapshot{
+		LoadedAt: now,
+		Document: document,
+	}
+	if !changed && now.Sub(impl.writtenAt) < snapshotWriteInterval {
+		return
+	}
+	if err := impl.write(); err != nil {
+		impl.deps.Logger.WithError(err).WithField("path", impl.path).Warn(ctx, "failed writing rates snapshot")
+		return
+	}
+	impl.writtenAt = now
+}
+
+// write replaces the file atomically, a crash mid write leaves the previous snapshot in place
+func (impl *ratesFallbackImpl) write() (err error) {
+	var content []byte
+	if content, err = json.Marshal(impl.snapshot); err != nil {
+		return
+	}
+	dir := filepath.Dir(impl.path)
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+	var file *os.File
+	if file, err = ioutil.TempFile(dir, filepath.Base(impl.path)); err != nil {
+		return
+	}
+	defer os.Remove(file.Name())
+	if _, err = file.Write(content); err != nil {
+		_ = file.Close()
+		return
+	}
+	if err = file.Close(); err != nil {
+		return
+	}
+	return os.Rename(file.Name(), impl.path)
+}
+
+// load returns the snapshot loaded by this instance, or the one persisted by a previous run, nil if there is none
+func (impl *ratesFallbackImpl) load(ctx context.Context) *ratesSnapshot {
+	impl.lock.Lock()
+	defer impl.lock.Unlock()
+	if impl.snapshot != nil {
+		return impl.snapshot
+	}
+	content, err := ioutil.ReadFile(impl.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			impl.deps.Logger.WithError(err).WithField("path", impl.path).Warn(ctx, "failed reading rates snapshot")
+		}
+		return nil
+	}
+	var snapshot ratesSnapshot
+	if err = json.Unmarshal(content, &snapshot); err != nil || snapshot.Document == nil {
+		impl.deps.Logger.WithError(err).WithField("path", impl.path).Warn(ctx, "invalid rates snapshot")
+		return nil
+	}
+	impl.snapshot = &snapshot
+	impl.writtenAt = snapshot.LoadedAt
+	return impl.snapshot
+}
@@ -0,0 +1,84 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+	"go.uber.org/fx"
+)
+
+const (
+	watchEnabledKey      = "exchangerate.watch.enabled"
+	watchPollIntervalKey = "exchangerate.watch.pollInterval"
+)
+
+// ratesWatcherImpl polls mongo for the latest rates and publishes them to the subscribers of this instance. The rates
+// are stored by whichever instance executed the Temporal activity, polling lets the subscribers of every instance know.
+type ratesWatcherImpl struct {
+	deps         currencyRateDaoImplDeps
+	dao          CurrencyRateDao
+	pollInterval time.Duration
+}
+
+// startRatesWatcher polls the given dao, it should read mongo directly so that cached or stale rates aren't published
+func startRatesWatcher(deps currencyRateDaoImplDeps, dao CurrencyRateDao) (err error) {
+	impl := &ratesWatcherImpl{
+		deps:         deps,
+		dao:          dao,
+		pollInterval: deps.Config.Get(watchPollIntervalKey).Duration(),
+	}
+	if impl.pollInterval <= 0 {
+		err = fmt.Errorf("%s must be positive, got %s", watchPollIntervalKey, impl.pollInterval)
+		return
+	}
+	var stop chan struct{}
+	var done chan struct{}
+	deps.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			stop = make(chan struct{})
+			done = make(chan struct{})
+			go impl.pollLoop(stop, done)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			close(stop)
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+	return
+}
+
+func (impl *ratesWatcherImpl) pollLoop(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(impl.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			impl.poll(context.Background())
+		}
+	}
+}
+
+// poll publishes the latest rates, the broadcaster drops them when they were published already
+func (impl *ratesWatcherImpl) poll(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, impl.pollInterval)
+	defer cancel()
+	var document *model.ExchangeRateDocument
+	var err error
+	if document, err = impl.dao.GetRates(ctx); err != nil {
+		impl.deps.Logger.WithError(err).Warn(ctx, "failed polling the latest rates")
+		return
+	}
+	if document != nil {
+		impl.deps.RatesBroadcaster.Publish(ctx, document)
+	}
+}
@@ -0,0 +1,116 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// AssetClass groups currencies that are fetched from the same providers on the same schedule
+type AssetClass string
+
+const (
+	AssetClassFiat   AssetClass = "fiat"
+	AssetClassCrypto AssetClass = "crypto"
+	AssetClassMetal  AssetClass = "metal"
+)
+
+var (
+	metals = map[string]bool{"XAU": true, "XAG": true, "XPT": true, "XPD": true}
+	// cryptos lists the crypto currencies that might be mistaken for fiat ones, codes that aren't ISO 4217 are crypto anyway
+	cryptos = map[string]bool{"BTC": true, "ETH": true, "LTC": true, "BCH": true, "XRP": true, "XLM": true}
+	// cryptoTickerPattern matches the crypto tickers that don't fit in three letters, e.g. USDT or DOGE
+	cryptoTickerPattern = regexp.MustCompile(`^[A-Z0-9]{4,10}$`)
+)
+
+// AssetClassOf classifies a currency code, codes outside of ISO 4217 are considered crypto currencies
+func AssetClassOf(code string) AssetClass {
+	switch _, known := currencies[code]; {
+	case metals[code]:
+		return AssetClassMetal
+	case cryptos[code] || !known:
+		return AssetClassCrypto
+	default:
+		return AssetClassFiat
+	}
+}
+
+// IsSupportedCurrency tells whether a code belongs to an asset class: the known currencies, and the longer tickers
+// AssetClassOf classifies as crypto. Unknown three letter codes are rejected, they're most likely misspelled ISO codes.
+func IsSupportedCurrency(code string) bool {
+	return IsKnownCurrency(code) || (AssetClassOf(code) == AssetClassCrypto && cryptoTickerPattern.MatchString(code))
+}
+
+// ParseAssetClass validates an asset class name, empty is fiat
+func ParseAssetClass(name string) (AssetClass, error) {
+	switch class := AssetClass(name); class {
+	case "":
+		return AssetClassFiat, nil
+	case AssetClassFiat, AssetClassCrypto, AssetClassMetal:
+		return class, nil
+	default:
+		return "", fmt.Errorf("unknown asset class %s", name)
+	}
+}
+
+// MinorUnits is the precision of the class currencies that have no ISO 4217 minor units, NoMinorUnits for fiat
+func (class AssetClass) MinorUnits() int {
+	switch class {
+	case AssetClassCrypto:
+		// satoshi
+		return 8
+	case AssetClassMetal:
+		return 6
+	default:
+		return NoMinorUnits
+	}
+}
+
+// OfAssetClass returns a copy of the document with only the currencies of the class and its base
+func (doc *ExchangeRateDocument) OfAssetClass(class AssetClass) *ExchangeRateDocument {
+	result := *doc
+	result.AssetClass = class
+	result.Rates = make(map[string]float32)
+	result.DecimalRates = make(map[string]Decimal)
+	for currency, rate := range doc.Rates {
+		if currency == doc.Base || AssetClassOf(currency) == class {
+			result.Rates[currency] = rate
+		}
+	}
+	for currency, rate := range doc.DecimalRates {
+		if currency == doc.Base || AssetClassOf(currency) == class {
+			result.DecimalRates[currency] = rate
+		}
+	}
+	return &result
+}
+
+// Merge returns a copy of the document overlaid with the rates of a document of another asset class.
+// The other document rates are expressed relative to this document base, its base must be one of the rates.
+// The creation time is kept, it's the time of the fiat rates the cross rates are calculated through.
+func (doc *ExchangeRateDocument) Merge(other *ExchangeRateDocument) (result *ExchangeRateDocument, err error) {
+	otherBase, ok := doc.DecimalRate(other.Base)
+	if !ok || !otherBase.IsPositive() {
+		err = fmt.Errorf("%s rates of base %s can't be merged into rates of base %s", other.AssetClass, other.Base, doc.Base)
+		return
+	}
+	merged := *doc
+	merged.Rates = make(map[string]float32, len(doc.Rates)+len(other.Rates))
+	merged.DecimalRates = make(map[string]Decimal, len(doc.Rates)+len(other.Rates))
+	for currency := range doc.Rates {
+		merged.DecimalRates[currency], _ = doc.DecimalRate(currency)
+	}
+	for currency := range other.Rates {
+		if currency == other.Base {
+			continue
+		}
+		rate, _ := other.DecimalRate(currency)
+		merged.DecimalRates[currency] = NewDecimal(rate.Mul(otherBase.Decimal).Round(rebasePrecision))
+	}
+	for currency, rate := range merged.DecimalRates {
+		value, _ := strconv.ParseFloat(rate.String(), 32)
+		merged.Rates[currency] = float32(value)
+	}
+	result = &merged
+	return
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// BackfillRequest is the range of the rates history to fill the gaps of
+type BackfillRequest struct {
+	Start time.Time
+	End   time.Time
+	// Interval is the slot size, either IntervalHourly or IntervalDaily
+	Interval RateHistoryInterval
+}
+
+// SlotSize returns the duration of a single slot of the interval
+func (request BackfillRequest) SlotSize() (size time.Duration, err error) {
+	switch request.Interval {
+	case IntervalHourly:
+		size = time.Hour
+	case IntervalDaily:
+		size = 24 * time.Hour
+	default:
+		err = fmt.Errorf("backfill interval must be hourly or daily, got %d", request.Interval)
+	}
+	return
+}
+
+// MissingSlots returns the start of every UTC slot within [start, end) that none of the existing creation times falls in
+func (request BackfillRequest) MissingSlots(existing []time.Time) (result []time.Time, err error) {
+	var size time.Duration
+	if size, err = request.SlotSize(); err != nil {
+		return
+	}
+	filled := make(map[time.Time]bool, len(existing))
+	for _, createdAt := range existing {
+		filled[createdAt.UTC().Truncate(size)] = true
+	}
+	for slot := request.Start.UTC().Truncate(size); slot.Before(request.End); slot = slot.Add(size) {
+		if slot.Before(request.Start) || filled[slot] {
+			continue
+		}
+		result = append(result, slot)
+	}
+	return
+}
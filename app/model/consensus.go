@@ -0,0 +1,123 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// ConsensusRates is the per currency median of several providers after dropping the outliers
+type ConsensusRates struct {
+	Rates ExchangeRatesModel
+	// Sources holds every provider rates rebased to the consensus base, including the rejected ones
+	Sources map[string]map[string]Decimal
+	// Rejected holds the providers that were dropped per currency
+	Rejected map[string][]string
+}
+
+// rebasePrecision is the number of decimal places kept when rebasing rates, crypto currencies quoted in fiat need plenty
+const rebasePrecision int32 = 20
+
+var basisPointsInOne = decimal.NewFromInt(10000)
+
+// Rebase expresses the rates relative to a different base currency, which must be one of the rates
+func (model ExchangeRatesModel) Rebase(base string) (result ExchangeRatesModel, err error) {
+	if model.Base == base {
+		return model, nil
+	}
+	baseRate, ok := model.Rates[base]
+	if !ok || !baseRate.IsPositive() {
+		err = fmt.Errorf("rates of base %s can't be rebased to %s", model.Base, base)
+		return
+	}
+	result = model
+	result.Base = base
+	result.Rates = make(map[string]Decimal, len(model.Rates))
+	for currency, rate := range model.Rates {
+		result.Rates[currency] = NewDecimal(rate.DivRound(baseRate.Decimal, rebasePrecision))
+	}
+	result.Rates[base] = NewDecimal(decimal.NewFromInt(1))
+	// some providers leave their own base out of the rates, it mustn't be lost when rebasing
+	if _, ok = model.Rates[model.Base]; !ok {
+		result.Rates[model.Base] = NewDecimal(decimal.NewFromInt(1).DivRound(baseRate.Decimal, rebasePrecision))
+	}
+	return
+}
+
+// Consensus calculates the median of every currency across the sources, values deviating from the median by more than
+// maxDeviationBps basis points are dropped and the median of the remaining ones is used.
+// Currencies quoted by a single source are kept as is. The result timestamp is the latest source timestamp.
+func Consensus(sources map[string]ExchangeRatesModel, base string, maxDeviationBps decimal.Decimal) (result ConsensusRates, err error) {
+	if len(sources) == 0 {
+		err = fmt.Errorf("no sources")
+		return
+	}
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	// map iteration order is random, the result must be deterministic to be used within a workflow
+	sort.Strings(names)
+
+	result = ConsensusRates{
+		Rates: ExchangeRatesModel{
+			Success: true,
+			Base:    base,
+			Rates:   make(map[string]Decimal),
+		},
+		Sources:  make(map[string]map[string]Decimal, len(sources)),
+		Rejected: make(map[string][]string),
+	}
+	quotes := make(map[string][]sourceRate)
+	for _, name := range names {
+		var rebased ExchangeRatesModel
+		if rebased, err = sources[name].Rebase(base); err != nil {
+			err = fmt.Errorf("source %s: %w", name, err)
+			return
+		}
+		if rebased.Timestamp > result.Rates.Timestamp {
+			result.Rates.Timestamp = rebased.Timestamp
+			result.Rates.Date = rebased.Date
+		}
+		result.Sources[name] = rebased.Rates
+		for currency, rate := range rebased.Rates {
+			quotes[currency] = append(quotes[currency], sourceRate{source: name, rate: rate.Decimal})
+		}
+	}
+	for currency, rates := range quotes {
+		consensus := median(rates)
+		accepted := make([]sourceRate, 0, len(rates))
+		for _, rate := range rates {
+			if consensus.IsPositive() && rate.rate.Sub(consensus).Abs().Mul(basisPointsInOne).GreaterThan(consensus.Mul(maxDeviationBps)) {
+				result.Rejected[currency] = append(result.Rejected[currency], rate.source)
+				continue
+			}
+			accepted = append(accepted, rate)
+		}
+		if len(accepted) == 0 {
+			// there is no consensus at all, keep the median rather than losing the currency
+			accepted = rates
+		}
+		result.Rates.Rates[currency] = NewDecimal(median(accepted))
+	}
+	return
+}
+
+type sourceRate struct {
+	source string
+	rate   decimal.Decimal
+}
+
+func median(rates []sourceRate) decimal.Decimal {
+	values := make([]decimal.Decimal, 0, len(rates))
+	for _, rate := range rates {
+		values = append(values, rate.rate)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].LessThan(values[j]) })
+	middle := len(values) / 2
+	if len(values)%2 == 1 {
+		return values[middle]
+	}
+	return values[middle-1].Add(values[middle]).Div(decimal.NewFromInt(2))
+}
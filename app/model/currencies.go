@@ -0,0 +1,205 @@
+package model
+
+// NoMinorUnits marks currencies that have no minor units, e.g. precious metals
+const NoMinorUnits = -1
+
+// Currency holds the ISO 4217 metadata of a currency
+type Currency struct {
+	Code string
+	Name string
+	// NumericCode is empty for codes outside of ISO 4217
+	NumericCode string
+	// MinorUnits is the number of decimal places, NoMinorUnits when not applicable
+	MinorUnits int
+	Symbol     string
+}
+
+// LookupCurrency returns the metadata of a known currency code
+func LookupCurrency(code string) (result Currency, ok bool) {
+	result, ok = currencies[code]
+	return
+}
+
+// IsKnownCurrency tells whether the code is an ISO 4217 code, a precious metal or a well known crypto currency
+func IsKnownCurrency(code string) bool {
+	_, known := currencies[code]
+	return known || metals[code] || cryptos[code]
+}
+
+var currencies = map[string]Currency{
+	"AED": {Code: "AED", Name: "UAE Dirham", NumericCode: "784", MinorUnits: 2, Symbol: "د.إ"},
+	"AFN": {Code: "AFN", Name: "Afghani", NumericCode: "971", MinorUnits: 2, Symbol: "؋"},
+	"ALL": {Code: "ALL", Name: "Lek", NumericCode: "008", MinorUnits: 2, Symbol: "L"},
+	"AMD": {Code: "AMD", Name: "Armenian Dram", NumericCode: "051", MinorUnits: 2, Symbol: "֏"},
+	"ANG": {Code: "ANG", Name: "Netherlands Antillean Guilder", NumericCode: "532", MinorUnits: 2, Symbol: "ƒ"},
+	"AOA": {Code: "AOA", Name: "Kwanza", NumericCode: "973", MinorUnits: 2, Symbol: "Kz"},
+	"ARS": {Code: "ARS", Name: "Argentine Peso", NumericCode: "032", MinorUnits: 2, Symbol: "$"},
+	"AUD": {Code: "AUD", Name: "Australian Dollar", NumericCode: "036", MinorUnits: 2, Symbol: "A$"},
+	"AWG": {Code: "AWG", Name: "Aruban Florin", NumericCode: "533", MinorUnits: 2, Symbol: "ƒ"},
+	"AZN": {Code: "AZN", Name: "Azerbaijan Manat", NumericCode: "944", MinorUnits: 2, Symbol: "₼"},
+	"BAM": {Code: "BAM", Name: "Convertible Mark", NumericCode: "977", MinorUnits: 2, Symbol: "KM"},
+	"BBD": {Code: "BBD", Name: "Barbados Dollar", NumericCode: "052", MinorUnits: 2, Symbol: "Bds$"},
+	"BDT": {Code: "BDT", Name: "Taka", NumericCode: "050", MinorUnits: 2, Symbol: "৳"},
+	"BGN": {Code: "BGN", Name: "Bulgarian Lev", NumericCode: "975", MinorUnits: 2, Symbol: "лв"},
+	"BHD": {Code: "BHD", Name: "Bahraini Dinar", NumericCode: "048", MinorUnits: 3, Symbol: ".د.ب"},
+	"BIF": {Code: "BIF", Name: "Burundi Franc", NumericCode: "108", MinorUnits: 0, Symbol: "FBu"},
+	"BMD": {Code: "BMD", Name: "Bermudian Dollar", NumericCode: "060", MinorUnits: 2, Symbol: "$"},
+	"BND": {Code: "BND", Name: "Brunei Dollar", NumericCode: "096", MinorUnits: 2, Symbol: "B$"},
+	"BOB": {Code: "BOB", Name: "Boliviano", NumericCode: "068", MinorUnits: 2, Symbol: "Bs."},
+	"BRL": {Code: "BRL", Name: "Brazilian Real", NumericCode: "986", MinorUnits: 2, Symbol: "R$"},
+	"BSD": {Code: "BSD", Name: "Bahamian Dollar", NumericCode: "044", MinorUnits: 2, Symbol: "B$"},
+	"BTC": {Code: "BTC", Name: "Bitcoin", NumericCode: "", MinorUnits: 8, Symbol: "₿"},
+	"BTN": {Code: "BTN", Name: "Ngultrum", NumericCode: "064", MinorUnits: 2, Symbol: "Nu."},
+	"BWP": {Code: "BWP", Name: "Pula", NumericCode: "072", MinorUnits: 2, Symbol: "P"},
+	"BYN": {Code: "BYN", Name: "Belarusian Ruble", NumericCode: "933", MinorUnits: 2, Symbol: "Br"},
+	"BYR": {Code: "BYR", Name: "Belarusian Ruble (2000-2016)", NumericCode: "974", MinorUnits: 0, Symbol: "Br"},
+	"BZD": {Code: "BZD", Name: "Belize Dollar", NumericCode: "084", MinorUnits: 2, Symbol: "BZ$"},
+	"CAD": {Code: "CAD", Name: "Canadian Dollar", NumericCode: "124", MinorUnits: 2, Symbol: "CA$"},
+	"CDF": {Code: "CDF", Name: "Congolese Franc", NumericCode: "976", MinorUnits: 2, Symbol: "FC"},
+	"CHF": {Code: "CHF", Name: "Swiss Franc", NumericCode: "756", MinorUnits: 2, Symbol: "CHF"},
+	"CLF": {Code: "CLF", Name: "Unidad de Fomento", NumericCode: "990", MinorUnits: 4, Symbol: "UF"},
+	"CLP": {Code: "CLP", Name: "Chilean Peso", NumericCode: "152", MinorUnits: 0, Symbol: "$"},
+	"CNY": {Code: "CNY", Name: "Yuan Renminbi", NumericCode: "156", MinorUnits: 2, Symbol: "¥"},
+	"COP": {Code: "COP", Name: "Colombian Peso", NumericCode: "170", MinorUnits: 2, Symbol: "$"},
+	"CRC": {Code: "CRC", Name: "Costa Rican Colon", NumericCode: "188", MinorUnits: 2, Symbol: "₡"},
+	"CUC": {Code: "CUC", Name: "Peso Convertible", NumericCode: "931", MinorUnits: 2, Symbol: "CUC$"},
+	"CUP": {Code: "CUP", Name: "Cuban Peso", NumericCode: "192", MinorUnits: 2, Symbol: "$"},
+	"CVE": {Code: "CVE", Name: "Cabo Verde Escudo", NumericCode: "132", MinorUnits: 2, Symbol: "Esc"},
+	"CZK": {Code: "CZK", Name: "Czech Koruna", NumericCode: "203", MinorUnits: 2, Symbol: "Kč"},
+	"DJF": {Code: "DJF", Name: "Djibouti Franc", NumericCode: "262", MinorUnits: 0, Symbol: "Fdj"},
+	"DKK": {Code: "DKK", Name: "Danish Krone", NumericCode: "208", MinorUnits: 2, Symbol: "kr"},
+	"DOP": {Code: "DOP", Name: "Dominican Peso", NumericCode: "214", MinorUnits: 2, Symbol: "RD$"},
+	"DZD": {Code: "DZD", Name: "Algerian Dinar", NumericCode: "012", MinorUnits: 2, Symbol: "د.ج"},
+	"EGP": {Code: "EGP", Name: "Egyptian Pound", NumericCode: "818", MinorUnits: 2, Symbol: "E£"},
+	"ERN": {Code: "ERN", Name: "Nakfa", NumericCode: "232", MinorUnits: 2, Symbol: "Nfk"},
+	"ETB": {Code: "ETB", Name: "Ethiopian Birr", NumericCode: "230", MinorUnits: 2, Symbol: "Br"},
+	"EUR": {Code: "EUR", Name: "Euro", NumericCode: "978", MinorUnits: 2, Symbol: "€"},
+	"FJD": {Code: "FJD", Name: "Fiji Dollar", NumericCode: "242", MinorUnits: 2, Symbol: "FJ$"},
+	"FKP": {Code: "FKP", Name: "Falkland Islands Pound", NumericCode: "238", MinorUnits: 2, Symbol: "£"},
+	"GBP": {Code: "GBP", Name: "Pound Sterling", NumericCode: "826", MinorUnits: 2, Symbol: "£"},
+	"GEL": {Code: "GEL", Name: "Lari", NumericCode: "981", MinorUnits: 2, Symbol: "₾"},
+	"GGP": {Code: "GGP", Name: "Guernsey Pound", NumericCode: "", MinorUnits: 2, Symbol: "£"},
+	"GHS": {Code: "GHS", Name: "Ghana Cedi", NumericCode: "936", MinorUnits: 2, Symbol: "GH₵"},
+	"GIP": {Code: "GIP", Name: "Gibraltar Pound", NumericCode: "292", MinorUnits: 2, Symbol: "£"},
+	"GMD": {Code: "GMD", Name: "Dalasi", NumericCode: "270", MinorUnits: 2, Symbol: "D"},
+	"GNF": {Code: "GNF", Name: "Guinean Franc", NumericCode: "324", MinorUnits: 0, Symbol: "FG"},
+	"GTQ": {Code: "GTQ", Name: "Quetzal", NumericCode: "320", MinorUnits: 2, Symbol: "Q"},
+	"GYD": {Code: "GYD", Name: "Guyana Dollar", NumericCode: "328", MinorUnits: 2, Symbol: "G$"},
+	"HKD": {Code: "HKD", Name: "Hong Kong Dollar", NumericCode: "344", MinorUnits: 2, Symbol: "HK$"},
+	"HNL": {Code: "HNL", Name: "Lempira", NumericCode: "340", MinorUnits: 2, Symbol: "L"},
+	"HRK": {Code: "HRK", Name: "Kuna", NumericCode: "191", MinorUnits: 2, Symbol: "kn"},
+	"HTG": {Code: "HTG", Name: "Gourde", NumericCode: "332", MinorUnits: 2, Symbol: "G"},
+	"HUF": {Code: "HUF", Name: "Forint", NumericCode: "348", MinorUnits: 2, Symbol: "Ft"},
+	"IDR": {Code: "IDR", Name: "Rupiah", NumericCode: "360", MinorUnits: 2, Symbol: "Rp"},
+	"ILS": {Code: "ILS", Name: "New Israeli Sheqel", NumericCode: "376", MinorUnits: 2, Symbol: "₪"},
+	"IMP": {Code: "IMP", Name: "Manx Pound", NumericCode: "", MinorUnits: 2, Symbol: "£"},
+	"INR": {Code: "INR", Name: "Indian Rupee", NumericCode: "356", MinorUnits: 2, Symbol: "₹"},
+	"IQD": {Code: "IQD", Name: "Iraqi Dinar", NumericCode: "368", MinorUnits: 3, Symbol: "ع.د"},
+	"IRR": {Code: "IRR", Name: "Iranian Rial", NumericCode: "364", MinorUnits: 2, Symbol: "﷼"},
+	"ISK": {Code: "ISK", Name: "Iceland Krona", NumericCode: "352", MinorUnits: 0, Symbol: "kr"},
+	"JEP": {Code: "JEP", Name: "Jersey Pound", NumericCode: "", MinorUnits: 2, Symbol: "£"},
+	"JMD": {Code: "JMD", Name: "Jamaican Dollar", NumericCode: "388", MinorUnits: 2, Symbol: "J$"},
+	"JOD": {Code: "JOD", Name: "Jordanian Dinar", NumericCode: "400", MinorUnits: 3, Symbol: "د.ا"},
+	"JPY": {Code: "JPY", Name: "Yen", NumericCode: "392", MinorUnits: 0, Symbol: "¥"},
+	"KES": {Code: "KES", Name: "Kenyan Shilling", NumericCode: "404", MinorUnits: 2, Symbol: "KSh"},
+	"KGS": {Code: "KGS", Name: "Som", NumericCode: "417", MinorUnits: 2, Symbol: "с"},
+	"KHR": {Code: "KHR", Name: "Riel", NumericCode: "116", MinorUnits: 2, Symbol: "៛"},
+	"KMF": {Code: "KMF", Name: "Comorian Franc", NumericCode: "174", MinorUnits: 0, Symbol: "CF"},
+	"KPW": {Code: "KPW", Name: "North Korean Won", NumericCode: "408", MinorUnits: 2, Symbol: "₩"},
+	"KRW": {Code: "KRW", Name: "Won", NumericCode: "410", MinorUnits: 0, Symbol: "₩"},
+	"KWD": {Code: "KWD", Name: "Kuwaiti Dinar", NumericCode: "414", MinorUnits: 3, Symbol: "د.ك"},
+	"KYD": {Code: "KYD", Name: "Cayman Islands Dollar", NumericCode: "136", MinorUnits: 2, Symbol: "CI$"},
+	"KZT": {Code: "KZT", Name: "Tenge", NumericCode: "398", MinorUnits: 2, Symbol: "₸"},
+	"LAK": {Code: "LAK", Name: "Lao Kip", NumericCode: "418", MinorUnits: 2, Symbol: "₭"},
+	"LBP": {Code: "LBP", Name: "Lebanese Pound", NumericCode: "422", MinorUnits: 2, Symbol: "ل.ل"},
+	"LKR": {Code: "LKR", Name: "Sri Lanka Rupee", NumericCode: "144", MinorUnits: 2, Symbol: "Rs"},
+	"LRD": {Code: "LRD", Name: "Liberian Dollar", NumericCode: "430", MinorUnits: 2, Symbol: "L$"},
+	"LSL": {Code: "LSL", Name: "Loti", NumericCode: "426", MinorUnits: 2, Symbol: "L"},
+	"LTL": {Code: "LTL", Name: "Lithuanian Litas", NumericCode: "440", MinorUnits: 2, Symbol: "Lt"},
+	"LVL": {Code: "LVL", Name: "Latvian Lats", NumericCode: "428", MinorUnits: 2, Symbol: "Ls"},
+	"LYD": {Code: "LYD", Name: "Libyan Dinar", NumericCode: "434", MinorUnits: 3, Symbol: "ل.د"},
+	"MAD": {Code: "MAD", Name: "Moroccan Dirham", NumericCode: "504", MinorUnits: 2, Symbol: "د.م."},
+	"MDL": {Code: "MDL", Name: "Moldovan Leu", NumericCode: "498", MinorUnits: 2, Symbol: "L"},
+	"MGA": {Code: "MGA", Name: "Malagasy Ariary", NumericCode: "969", MinorUnits: 2, Symbol: "Ar"},
+	"MKD": {Code: "MKD", Name: "Denar", NumericCode: "807", MinorUnits: 2, Symbol: "ден"},
+	"MMK": {Code: "MMK", Name: "Kyat", NumericCode: "104", MinorUnits: 2, Symbol: "K"},
+	"MNT": {Code: "MNT", Name: "Tugrik", NumericCode: "496", MinorUnits: 2, Symbol: "₮"},
+	"MOP": {Code: "MOP", Name: "Pataca", NumericCode: "446", MinorUnits: 2, Symbol: "MOP$"},
+	"MRO": {Code: "MRO", Name: "Ouguiya (1973-2017)", NumericCode: "478", MinorUnits: 2, Symbol: "UM"},
+	"MRU": {Code: "MRU", Name: "Ouguiya", NumericCode: "929", MinorUnits: 2, Symbol: "UM"},
+	"MUR": {Code: "MUR", Name: "Mauritius Rupee", NumericCode: "480", MinorUnits: 2, Symbol: "₨"},
+	"MVR": {Code: "MVR", Name: "Rufiyaa", NumericCode: "462", MinorUnits: 2, Symbol: "Rf"},
+	"MWK": {Code: "MWK", Name: "Malawi Kwacha", NumericCode: "454", MinorUnits: 2, Symbol: "MK"},
+	"MXN": {Code: "MXN", Name: "Mexican Peso", NumericCode: "484", MinorUnits: 2, Symbol: "MX$"},
+	"MYR": {Code: "MYR", Name: "Malaysian Ringgit", NumericCode: "458", MinorUnits: 2, Symbol: "RM"},
+	"MZN": {Code: "MZN", Name: "Mozambique Metical", NumericCode: "943", MinorUnits: 2, Symbol: "MT"},
+	"NAD": {Code: "NAD", Name: "Namibia Dollar", NumericCode: "516", MinorUnits: 2, Symbol: "N$"},
+	"NGN": {Code: "NGN", Name: "Naira", NumericCode: "566", MinorUnits: 2, Symbol: "₦"},
+	"NIO": {Code: "NIO", Name: "Cordoba Oro", NumericCode: "558", MinorUnits: 2, Symbol: "C$"},
+	"NOK": {Code: "NOK", Name: "Norwegian Krone", NumericCode: "578", MinorUnits: 2, Symbol: "kr"},
+	"NPR": {Code: "NPR", Name: "Nepalese Rupee", NumericCode: "524", MinorUnits: 2, Symbol: "₨"},
+	"NZD": {Code: "NZD", Name: "New Zealand Dollar", NumericCode: "554", MinorUnits: 2, Symbol: "NZ$"},
+	"OMR": {Code: "OMR", Name: "Rial Omani", NumericCode: "512", MinorUnits: 3, Symbol: "ر.ع."},
+	"PAB": {Code: "PAB", Name: "Balboa", NumericCode: "590", MinorUnits: 2, Symbol: "B/."},
+	"PEN": {Code: "PEN", Name: "Sol", NumericCode: "604", MinorUnits: 2, Symbol: "S/"},
+	"PGK": {Code: "PGK", Name: "Kina", NumericCode: "598", MinorUnits: 2, Symbol: "K"},
+	"PHP": {Code: "PHP", Name: "Philippine Peso", NumericCode: "608", MinorUnits: 2, Symbol: "₱"},
+	"PKR": {Code: "PKR", Name: "Pakistan Rupee", NumericCode: "586", MinorUnits: 2, Symbol: "₨"},
+	"PLN": {Code: "PLN", Name: "Zloty", NumericCode: "985", MinorUnits: 2, Symbol: "zł"},
+	"PYG": {Code: "PYG", Name: "Guarani", NumericCode: "600", MinorUnits: 0, Symbol: "₲"},
+	"QAR": {Code: "QAR", Name: "Qatari Rial", NumericCode: "634", MinorUnits: 2, Symbol: "ر.ق"},
+	"RON": {Code: "RON", Name: "Romanian Leu", NumericCode: "946", MinorUnits: 2, Symbol: "lei"},
+	"RSD": {Code: "RSD", Name: "Serbian Dinar", NumericCode: "941", MinorUnits: 2, Symbol: "дин."},
+	"RUB": {Code: "RUB", Name: "Russian Ruble", NumericCode: "643", MinorUnits: 2, Symbol: "₽"},
+	"RWF": {Code: "RWF", Name: "Rwanda Franc", NumericCode: "646", MinorUnits: 0, Symbol: "FRw"},
+	"SAR": {Code: "SAR", Name: "Saudi Riyal", NumericCode: "682", MinorUnits: 2, Symbol: "ر.س"},
+	"SBD": {Code: "SBD", Name: "Solomon Islands Dollar", NumericCode: "090", MinorUnits: 2, Symbol: "SI$"},
+	"SCR": {Code: "SCR", Name: "Seychelles Rupee", NumericCode: "690", MinorUnits: 2, Symbol: "₨"},
+	"SDG": {Code: "SDG", Name: "Sudanese Pound", NumericCode: "938", MinorUnits: 2, Symbol: "ج.س."},
+	"SEK": {Code: "SEK", Name: "Swedish Krona", NumericCode: "752", MinorUnits: 2, Symbol: "kr"},
+	"SGD": {Code: "SGD", Name: "Singapore Dollar", NumericCode: "702", MinorUnits: 2, Symbol: "S$"},
+	"SHP": {Code: "SHP", Name: "Saint Helena Pound", NumericCode: "654", MinorUnits: 2, Symbol: "£"},
+	"SLE": {Code: "SLE", Name: "Leone", NumericCode: "925", MinorUnits: 2, Symbol: "Le"},
+	"SLL": {Code: "SLL", Name: "Leone (1964-2022)", NumericCode: "694", MinorUnits: 2, Symbol: "Le"},
+	"SOS": {Code: "SOS", Name: "Somali Shilling", NumericCode: "706", MinorUnits: 2, Symbol: "Sh"},
+	"SRD": {Code: "SRD", Name: "Surinam Dollar", NumericCode: "968", MinorUnits: 2, Symbol: "$"},
+	"SSP": {Code: "SSP", Name: "South Sudanese Pound", NumericCode: "728", MinorUnits: 2, Symbol: "£"},
+	"STD": {Code: "STD", Name: "Dobra (1977-2017)", NumericCode: "678", MinorUnits: 2, Symbol: "Db"},
+	"STN": {Code: "STN", Name: "Dobra", NumericCode: "930", MinorUnits: 2, Symbol: "Db"},
+	"SVC": {Code: "SVC", Name: "El Salvador Colon", NumericCode: "222", MinorUnits: 2, Symbol: "₡"},
+	"SYP": {Code: "SYP", Name: "Syrian Pound", NumericCode: "760", MinorUnits: 2, Symbol: "£S"},
+	"SZL": {Code: "SZL", Name: "Lilangeni", NumericCode: "748", MinorUnits: 2, Symbol: "E"},
+	"THB": {Code: "THB", Name: "Baht", NumericCode: "764", MinorUnits: 2, Symbol: "฿"},
+	"TJS": {Code: "TJS", Name: "Somoni", NumericCode: "972", MinorUnits: 2, Symbol: "SM"},
+	"TMT": {Code: "TMT", Name: "Turkmenistan New Manat", NumericCode: "934", MinorUnits: 2, Symbol: "m"},
+	"TND": {Code: "TND", Name: "Tunisian Dinar", NumericCode: "788", MinorUnits: 3, Symbol: "د.ت"},
+	"TOP": {Code: "TOP", Name: "Pa'anga", NumericCode: "776", MinorUnits: 2, Symbol: "T$"},
+	"TRY": {Code: "TRY", Name: "Turkish Lira", NumericCode: "949", MinorUnits: 2, Symbol: "₺"},
+	"TTD": {Code: "TTD", Name: "Trinidad and Tobago Dollar", NumericCode: "780", MinorUnits: 2, Symbol: "TT$"},
+	"TWD": {Code: "TWD", Name: "New Taiwan Dollar", NumericCode: "901", MinorUnits: 2, Symbol: "NT$"},
+	"TZS": {Code: "TZS", Name: "Tanzanian Shilling", NumericCode: "834", MinorUnits: 2, Symbol: "TSh"},
+	"UAH": {Code: "UAH", Name: "Hryvnia", NumericCode: "980", MinorUnits: 2, Symbol: "₴"},
+	"UGX": {Code: "UGX", Name: "Uganda Shilling", NumericCode: "800", MinorUnits: 0, Symbol: "USh"},
+	"USD": {Code: "USD", Name: "US Dollar", NumericCode: "840", MinorUnits: 2, Symbol: "$"},
+	"UYU": {Code: "UYU", Name: "Peso Uruguayo", NumericCode: "858", MinorUnits: 2, Symbol: "$U"},
+	"UZS": {Code: "UZS", Name: "Uzbekistan Sum", NumericCode: "860", MinorUnits: 2, Symbol: "soʻm"},
+	"VEF": {Code: "VEF", Name: "Bolivar (2008-2018)", NumericCode: "937", MinorUnits: 2, Symbol: "Bs.F"},
+	"VES": {Code: "VES", Name: "Bolivar Soberano", NumericCode: "928", MinorUnits: 2, Symbol: "Bs.S"},
+	"VND": {Code: "VND", Name: "Dong", NumericCode: "704", MinorUnits: 0, Symbol: "₫"},
+	"VUV": {Code: "VUV", Name: "Vatu", NumericCode: "548", MinorUnits: 0, Symbol: "VT"},
+	"WST": {Code: "WST", Name: "Tala", NumericCode: "882", MinorUnits: 2, Symbol: "WS$"},
+	"XAF": {Code: "XAF", Name: "CFA Franc BEAC", NumericCode: "950", MinorUnits: 0, Symbol: "FCFA"},
+	"XAG": {Code: "XAG", Name: "Silver", NumericCode: "961", MinorUnits: NoMinorUnits, Symbol: "XAG"},
+	"XAU": {Code: "XAU", Name: "Gold", NumericCode: "959", MinorUnits: NoMinorUnits, Symbol: "XAU"},
+	"XCD": {Code: "XCD", Name: "East Caribbean Dollar", NumericCode: "951", MinorUnits: 2, Symbol: "EC$"},
+	"XDR": {Code: "XDR", Name: "SDR (Special Drawing Right)", NumericCode: "960", MinorUnits: NoMinorUnits, Symbol: "SDR"},
+	"XOF": {Code: "XOF", Name: "CFA Franc BCEAO", NumericCode: "952", MinorUnits: 0, Symbol: "CFA"},
+	"XPD": {Code: "XPD", Name: "Palladium", NumericCode: "964", MinorUnits: NoMinorUnits, Symbol: "XPD"},
+	"XPF": {Code: "XPF", Name: "CFP Franc", NumericCode: "953", MinorUnits: 0, Symbol: "₣"},
+	"XPT": {Code: "XPT", Name: "Platinum", NumericCode: "962", MinorUnits: NoMinorUnits, Symbol: "XPT"},
+	"YER": {Code: "YER", Name: "Yemeni Rial", NumericCode: "886", MinorUnits: 2, Symbol: "﷼"},
+	"ZAR": {Code: "ZAR", Name: "Rand", NumericCode: "710", MinorUnits: 2, Symbol: "R"},
+	"ZMK": {Code: "ZMK", Name: "Zambian Kwacha (1968-2012)", NumericCode: "894", MinorUnits: 2, Symbol: "ZK"},
+	"ZMW": {Code: "ZMW", Name: "Zambian Kwacha", NumericCode: "967", MinorUnits: 2, Symbol: "ZK"},
+	"ZWL": {Code: "ZWL", Name: "Zimbabwe Dollar", NumericCode: "932", MinorUnits: 2, Symbol: "Z$"},
+}
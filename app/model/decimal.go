@@ -0,0 +1,42 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Decimal is an exact decimal number, it's stored in mongo as Decimal128 and (un)marshaled as a JSON number or string
+type Decimal struct {
+	decimal.Decimal
+}
+
+func NewDecimal(value decimal.Decimal) Decimal {
+	return Decimal{Decimal: value}
+}
+
+func (d Decimal) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	value, err := primitive.ParseDecimal128(d.String())
+	if err != nil {
+		return 0, nil, fmt.Errorf("%s can't be represented as Decimal128: %w", d.String(), err)
+	}
+	return bson.MarshalValue(value)
+}
+
+func (d *Decimal) UnmarshalBSONValue(bsonType bsontype.Type, data []byte) (err error) {
+	raw := bson.RawValue{Type: bsonType, Value: data}
+	switch bsonType {
+	case bsontype.Decimal128:
+		d.Decimal, err = decimal.NewFromString(raw.Decimal128().String())
+	case bsontype.Double:
+		d.Decimal = decimal.NewFromFloat(raw.Double())
+	case bsontype.String:
+		d.Decimal, err = decimal.NewFromString(raw.StringValue())
+	default:
+		err = fmt.Errorf("cannot decode %s into a decimal", bsonType)
+	}
+	return
+}
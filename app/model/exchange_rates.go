@@ -1,26 +1,70 @@
 package model
 
-import "time"
+import (
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type ExchangeRatesModel struct {
 	Success   bool               `json:"success"`
 	Date      string             `json:"date"`
 	Base      string             `json:"base"`
 	Timestamp int64              `json:"timestamp"`
-	Rates     map[string]float32 `json:"rates"`
+	Rates     map[string]Decimal `json:"rates"`
 }
 
 type ExchangeRateDocument struct {
-	Base      string             `bson:"base"`
-	Rates     map[string]float32 `bson:"rates"`
-	CreatedAt time.Time          `bson:"created_at"`
+	// Base is the canonical base when one is configured, the rates are rebased to it
+	Base  string             `bson:"base"`
+	Rates map[string]float32 `bson:"rates"`
+	// DecimalRates holds the exact rates, documents stored before it was introduced don't have it
+	DecimalRates map[string]Decimal `bson:"decimal_rates,omitempty"`
+	CreatedAt    time.Time          `bson:"created_at"`
+	// Provider is the name of the rates provider the document was fetched from
+	Provider string `bson:"provider,omitempty"`
+	// ProviderBase is the base the provider returned the rates in, before rebasing them to Base
+	ProviderBase string `bson:"provider_base,omitempty"`
+	// AssetClass of the rates, documents without it are fiat documents which might include rates of other classes too
+	AssetClass AssetClass `bson:"asset_class,omitempty"`
+	// Sources holds the rates of every provider of a consensus document, rebased to Base, for audit
+	Sources map[string]map[string]Decimal `bson:"sources,omitempty"`
+	// Rejected holds the providers whose rate was dropped as an outlier, per currency
+	Rejected map[string][]string `bson:"rejected,omitempty"`
+	// SnapshotAt is set on documents served from the last-known-good snapshot while mongo can't be reached, it's when
+	// the snapshot was last loaded from mongo
+	SnapshotAt time.Time `bson:"-" json:"-"`
 }
 
 func ConvertExchangeRatesModel(model ExchangeRatesModel) (result *ExchangeRateDocument) {
 	result = &ExchangeRateDocument{
-		Base:      model.Base,
-		Rates:     model.Rates,
-		CreatedAt: time.Unix(model.Timestamp, 0),
+		Base:         model.Base,
+		Rates:        make(map[string]float32, len(model.Rates)),
+		DecimalRates: model.Rates,
+		CreatedAt:    time.Unix(model.Timestamp, 0),
+	}
+	for currency, rate := range model.Rates {
+		// same rounding as decoding the provider JSON directly into a float32
+		value, _ := strconv.ParseFloat(rate.String(), 32)
+		result.Rates[currency] = float32(value)
 	}
 	return
 }
+
+// DecimalRate returns the exact rate of a currency, falling back to the float one for older documents
+func (doc *ExchangeRateDocument) DecimalRate(currency string) (result Decimal, ok bool) {
+	if result, ok = doc.DecimalRates[currency]; ok {
+		return
+	}
+	var rate float32
+	if rate, ok = doc.Rates[currency]; ok {
+		result = NewDecimal(decimal.NewFromFloat32(rate))
+	}
+	return
+}
+
+// Stale tells whether the document was served from the last-known-good snapshot
+func (doc *ExchangeRateDocument) Stale() bool {
+	return !doc.SnapshotAt.IsZero()
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// QuoteDocument is a cross rate locked until ExpiresAt
+type QuoteDocument struct {
+	ID           string  `bson:"_id"`
+	CurrencyFrom string  `bson:"currency_from"`
+	CurrencyTo   string  `bson:"currency_to"`
+	Rate         Decimal `bson:"rate"`
+	ClientID     string  `bson:"client_id,omitempty"`
+	// RatesCreatedAt is the creation time of the rates document the rate was locked from
+	RatesCreatedAt time.Time `bson:"rates_created_at"`
+	CreatedAt      time.Time `bson:"created_at"`
+	ExpiresAt      time.Time `bson:"expires_at"`
+}
+
+// Expired is true once the quote can't be honoured anymore
+func (doc *QuoteDocument) Expired(now time.Time) bool {
+	return !now.Before(doc.ExpiresAt)
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+type RateHistoryInterval int
+
+const (
+	// IntervalRaw returns every stored document as its own point
+	IntervalRaw RateHistoryInterval = iota
+	IntervalHourly
+	IntervalDaily
+	// IntervalWeekly buckets start on Monday 00:00 UTC
+	IntervalWeekly
+)
+
+// RateHistoryPoint is the OHLC aggregation of the cross rates stored within a single bucket
+type RateHistoryPoint struct {
+	Start   time.Time `bson:"_id"`
+	Open    float64   `bson:"open"`
+	High    float64   `bson:"high"`
+	Low     float64   `bson:"low"`
+	Close   float64   `bson:"close"`
+	Samples int32     `bson:"samples"`
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// QuarantinedRatesDocument is a rates document that failed validation, it's kept for review and never served
+type QuarantinedRatesDocument struct {
+	ExchangeRateDocument `bson:",inline"`
+	// Violations holds the reasons the document was rejected for
+	Violations    []string  `bson:"violations"`
+	QuarantinedAt time.Time `bson:"quarantined_at"`
+}
+
+var hundred = decimal.NewFromInt(100)
+
+// Validate returns the reasons the document shouldn't be stored as the latest rates, none when it's valid.
+// previous is the latest stored document, if any, rates of the same creation time are valid and stored once. Every currency
+// rate relative to the base is compared to the previous document, a rate that moved by more than maxChangePercent is a
+// violation, 0 disables the comparison. The comparison is always made with the stored rates, so a genuine move is
+// rejected on every run until the rates are stored by other means or maxChangePercent is raised.
+func (doc *ExchangeRateDocument) Validate(previous *ExchangeRateDocument, maxChangePercent decimal.Decimal) (violations []string) {
+	violations = doc.validateRates()
+	if previous == nil {
+		return
+	}
+	if doc.CreatedAt.Before(previous.CreatedAt) {
+		violations = append(violations, fmt.Sprintf("rates of %s are older than the latest stored rates of %s",
+			doc.CreatedAt.UTC().Format(time.RFC3339), previous.CreatedAt.UTC().Format(time.RFC3339)))
+	}
+	if len(violations) > 0 {
+		return
+	}
+	return doc.validateChange(previous, maxChangePercent)
+}
+
+// ValidateHistorical is Validate for backfilled rates, previous is the stored document right before them rather than the
+// latest one, so the rates aren't required to be newer
+func (doc *ExchangeRateDocument) ValidateHistorical(previous *ExchangeRateDocument, maxChangePercent decimal.Decimal) (violations []string) {
+	if violations = doc.validateRates(); len(violations) > 0 || previous == nil {
+		return
+	}
+	return doc.validateChange(previous, maxChangePercent)
+}
+
+// validateRates checks the document on its own
+func (doc *ExchangeRateDocument) validateRates() (violations []string) {
+	if len(doc.Base) == 0 {
+		violations = append(violations, "missing base currency")
+	}
+	if len(doc.Rates) == 0 {
+		violations = append(violations, "no rates")
+	}
+	currencies := make([]string, 0, len(doc.Rates))
+	for currency := range doc.Rates {
+		currencies = append(currencies, currency)
+	}
+	// sorted so that the violations of the same document are always reported in the same order
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		rate := doc.Rates[currency]
+		if math.IsNaN(float64(rate)) || math.IsInf(float64(rate), 0) || rate <= 0 {
+			violations = append(violations, fmt.Sprintf("invalid %s rate %v", currency, rate))
+		} else if exact, ok := doc.DecimalRate(currency); ok && !exact.IsPositive() {
+			violations = append(violations, fmt.Sprintf("invalid %s rate %s", currency, exact.String()))
+		}
+	}
+	return
+}
+
+// validateChange compares the rates to the previous ones, the rates of the document are expected to be valid
+func (doc *ExchangeRateDocument) validateChange(previous *ExchangeRateDocument, maxChangePercent decimal.Decimal) (violations []string) {
+	// providers stamp every fetch of the same publication with the same time, such rates were compared when stored
+	if !maxChangePercent.IsPositive() || doc.CreatedAt.Equal(previous.CreatedAt) {
+		return
+	}
+	currencies := make([]string, 0, len(doc.Rates))
+	for currency := range doc.Rates {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	// the previous rates might have a different base, they're expressed relative to the document base first
+	previousBase, ok := previous.DecimalRate(doc.Base)
+	if !ok || !previousBase.IsPositive() {
+		return
+	}
+	for _, currency := range currencies {
+		previousRate, ok := previous.DecimalRate(currency)
+		if !ok || !previousRate.IsPositive() {
+			continue
+		}
+		rate, _ := doc.DecimalRate(currency)
+		previousRate = NewDecimal(previousRate.DivRound(previousBase.Decimal, rebasePrecision))
+		change := rate.Sub(previousRate.Decimal).Abs().Mul(hundred).Div(previousRate.Decimal)
+		if change.GreaterThan(maxChangePercent) {
+			violations = append(violations, fmt.Sprintf("%s/%s moved by %s%% from %s to %s",
+				doc.Base, currency, change.StringFixed(2), previousRate.String(), rate.String()))
+		}
+	}
+	return
+}
@@ -0,0 +1,48 @@
+package model
+
+import "github.com/shopspring/decimal"
+
+type RoundingMode int
+
+const (
+	RoundingNone RoundingMode = iota
+	// RoundingHalfEven rounds half to even, also known as banker's rounding
+	RoundingHalfEven
+	// RoundingHalfUp rounds half away from zero
+	RoundingHalfUp
+	// RoundingDown rounds towards zero
+	RoundingDown
+	// RoundingUp rounds away from zero
+	RoundingUp
+)
+
+// RoundToMinorUnits rounds an amount to the ISO 4217 minor units of the currency. Crypto currencies and precious metals
+// without minor units are rounded to the precision of their asset class, other amounts are returned as is.
+func RoundToMinorUnits(amount decimal.Decimal, currency string, mode RoundingMode) decimal.Decimal {
+	minorUnits := NoMinorUnits
+	if info, ok := LookupCurrency(currency); ok {
+		minorUnits = info.MinorUnits
+	}
+	if minorUnits == NoMinorUnits {
+		minorUnits = AssetClassOf(currency).MinorUnits()
+	}
+	if minorUnits == NoMinorUnits {
+		return amount
+	}
+	return roundTo(amount, int32(minorUnits), mode)
+}
+
+func roundTo(amount decimal.Decimal, places int32, mode RoundingMode) decimal.Decimal {
+	switch mode {
+	case RoundingHalfEven:
+		return amount.RoundBank(places)
+	case RoundingHalfUp:
+		return amount.Round(places)
+	case RoundingDown:
+		return amount.RoundDown(places)
+	case RoundingUp:
+		return amount.RoundUp(places)
+	default:
+		return amount
+	}
+}
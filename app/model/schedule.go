@@ -0,0 +1,15 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/robfig/cron"
+)
+
+// ValidateCronSchedule makes sure the spec is a standard 5 fields cron spec or a descriptor such as @hourly
+func ValidateCronSchedule(spec string) (err error) {
+	if _, err = cron.ParseStandard(spec); err != nil {
+		err = fmt.Errorf("invalid cron schedule %q: %w", spec, err)
+	}
+	return
+}
@@ -5,7 +5,9 @@ import (
 	"github.com/bevgene/go-currency-rate/app/data"
 
 	currencyconverter "github.com/bevgene/go-currency-rate/api"
+	currencyconverterv2 "github.com/bevgene/go-currency-rate/api/v2"
 	"github.com/bevgene/go-currency-rate/app/controllers"
+	"github.com/bevgene/go-currency-rate/app/pricing"
 	"github.com/bevgene/go-currency-rate/app/services"
 	"github.com/bevgene/go-currency-rate/app/validations"
 	serverInt "github.com/go-masonry/mortar/interfaces/http/server"
@@ -20,7 +22,9 @@ type workshopServiceDeps struct {
 	fx.In
 
 	// API Implementations, "Register" them as GRPCServiceAPI
-	CurrencyRateFetcher currencyconverter.CurrencyConverterServer
+	CurrencyRateFetcher   currencyconverter.CurrencyConverterServer
+	CurrencyRateFetcherV2 currencyconverterv2.CurrencyConverterServer
+	Admin                 currencyconverter.CurrencyConverterAdminServer
 }
 
 func ServiceAPIsAndOtherDependenciesFxOption() fx.Option {
@@ -43,6 +47,8 @@ func ServiceAPIsAndOtherDependenciesFxOption() fx.Option {
 func serviceGRPCServiceAPIs(deps workshopServiceDeps) serverInt.GRPCServerAPI {
 	return func(srv *grpc.Server) {
 		currencyconverter.RegisterCurrencyConverterServer(srv, deps.CurrencyRateFetcher)
+		currencyconverterv2.RegisterCurrencyConverterServer(srv, deps.CurrencyRateFetcherV2)
+		currencyconverter.RegisterCurrencyConverterAdminServer(srv, deps.Admin)
 		// Any additional gRPC Implementations should be called here
 	}
 }
@@ -53,6 +59,12 @@ func serviceGRPCGatewayHandlers() []serverInt.GRPCGatewayGeneratedHandlers {
 		func(mux *runtime.ServeMux, localhostEndpoint string) error {
 			return currencyconverter.RegisterCurrencyConverterHandlerFromEndpoint(context.Background(), mux, localhostEndpoint, []grpc.DialOption{grpc.WithInsecure()})
 		},
+		func(mux *runtime.ServeMux, localhostEndpoint string) error {
+			return currencyconverterv2.RegisterCurrencyConverterHandlerFromEndpoint(context.Background(), mux, localhostEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+		},
+		func(mux *runtime.ServeMux, localhostEndpoint string) error {
+			return currencyconverter.RegisterCurrencyConverterAdminHandlerFromEndpoint(context.Background(), mux, localhostEndpoint, []grpc.DialOption{grpc.WithInsecure()})
+		},
 		// Any additional gRPC gateway registrations should be called here
 	}
 }
@@ -60,8 +72,16 @@ func serviceGRPCGatewayHandlers() []serverInt.GRPCGatewayGeneratedHandlers {
 func serviceDependencies() fx.Option {
 	return fx.Provide(
 		services.CreateCurrencyRateService,
+		services.CreateCurrencyRateServiceV2,
+		services.CreateAdminService,
 		controllers.CreateCurrencyRateController,
+		controllers.CreateCurrencyRateControllerV2,
+		controllers.CreateAdminController,
 		validations.CreateCurrencyRateValidations,
+		validations.CreateAdminValidations,
 		data.CreateCurrencyRateDao,
+		data.CreateRatesBroadcaster,
+		data.CreateQuoteDao,
+		pricing.CreatePricer,
 	)
 }
@@ -7,6 +7,9 @@ import (
 
 func ExchangeFxOptions() fx.Option {
 	return fx.Options(
-		fx.Provide(clients.CreateExchangeClient),
+		fx.Provide(
+			clients.CreateRateProviders,
+			clients.CreateExchangeClient,
+		),
 	)
 }
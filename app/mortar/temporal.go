@@ -12,10 +12,14 @@ func TemporalFxOptions() fx.Option {
 			clients.CreateTemporalClient,
 		),
 		fx.Invoke(temporal.CreateWorker),
-		fx.Invoke(temporal.CreateCronStarter),
+		fx.Invoke(temporal.CreateScheduleStarter),
 		fx.Provide(
 			temporal.CreateUpdateRatesWorkflow,
+			temporal.CreateBackfillRatesWorkflow,
+			temporal.CreateRatesSchedulerWorkflow,
 			temporal.CreateActivities,
+			temporal.CreateRatesRefresher,
+			temporal.CreateScheduleManager,
 		),
 	)
 }
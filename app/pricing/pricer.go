@@ -0,0 +1,182 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-masonry/mortar/interfaces/cfg"
+	"github.com/go-masonry/mortar/interfaces/log"
+	"github.com/shopspring/decimal"
+	"go.uber.org/fx"
+)
+
+type (
+	Pricer interface {
+		// Price applies the rule matching the client or the currency pair to a mid-market converted amount
+		Price(ctx context.Context, clientID, currencyFrom, currencyTo string, midMarketAmount decimal.Decimal) Breakdown
+	}
+
+	// Breakdown of the final amount, Amount = MidMarketAmount - SpreadAmount - MarkupAmount - FeeAmount
+	Breakdown struct {
+		MidMarketAmount decimal.Decimal
+		SpreadAmount    decimal.Decimal
+		MarkupAmount    decimal.Decimal
+		FeeAmount       decimal.Decimal
+		Amount          decimal.Decimal
+		// Priced is false when no pricing was applied and Amount equals MidMarketAmount
+		Priced bool
+	}
+
+	pricerImplDeps struct {
+		fx.In
+
+		Logger log.Logger
+		Config cfg.Config
+	}
+
+	pricerImpl struct {
+		deps pricerImplDeps
+		// trustClientID is set when a gateway authenticates the callers, client_id is theirs to choose otherwise
+		trustClientID bool
+		defaultRule   rule
+		pairRules     map[string]rule
+		clientRules   map[string]rule
+	}
+
+	ruleConfig struct {
+		BuySpreadBps  float64 `mapstructure:"buySpreadBps"`
+		SellSpreadBps float64 `mapstructure:"sellSpreadBps"`
+		MarkupPercent float64 `mapstructure:"markupPercent"`
+		FixedFee      float64 `mapstructure:"fixedFee"`
+	}
+
+	pricingConfig struct {
+		Default ruleConfig            `mapstructure:"default"`
+		Pairs   map[string]ruleConfig `mapstructure:"pairs"`
+		Clients map[string]ruleConfig `mapstructure:"clients"`
+		// TrustClientID applies the client rules, client_id isn't authenticated by this service
+		TrustClientID bool `mapstructure:"trustClientId"`
+	}
+
+	rule struct {
+		buySpread     decimal.Decimal
+		sellSpread    decimal.Decimal
+		markupPercent decimal.Decimal
+		fixedFee      decimal.Decimal
+	}
+)
+
+const (
+	pricingKey = "exchangerate.pricing"
+
+	basisPointsInOne = 10000
+	percentsInOne    = 100
+)
+
+func CreatePricer(deps pricerImplDeps) (result Pricer, err error) {
+	var config pricingConfig
+	if err = deps.Config.Get(pricingKey).Unmarshal(&config); err != nil {
+		err = fmt.Errorf("invalid %s configuration: %w", pricingKey, err)
+		return
+	}
+	impl := &pricerImpl{
+		deps:          deps,
+		trustClientID: config.TrustClientID,
+		pairRules:     make(map[string]rule, len(config.Pairs)),
+		clientRules:   make(map[string]rule, len(config.Clients)),
+	}
+	if impl.defaultRule, err = config.Default.rule(pricingKey + ".default"); err != nil {
+		return
+	}
+	// configuration keys are case insensitive
+	for pair, pairConfig := range config.Pairs {
+		if impl.pairRules[strings.ToLower(pair)], err = pairConfig.rule(pricingKey + ".pairs." + pair); err != nil {
+			return
+		}
+	}
+	for client, clientConfig := range config.Clients {
+		if impl.clientRules[strings.ToLower(client)], err = clientConfig.rule(pricingKey + ".clients." + client); err != nil {
+			return
+		}
+	}
+	if len(impl.clientRules) > 0 && !impl.trustClientID {
+		deps.Logger.Warn(context.Background(), "client pricing rules are ignored, %s.trustClientId isn't set", pricingKey)
+	}
+	result = impl
+	return
+}
+
+func (impl *pricerImpl) Price(ctx context.Context, clientID, currencyFrom, currencyTo string, midMarketAmount decimal.Decimal) (result Breakdown) {
+	result = Breakdown{
+		MidMarketAmount: midMarketAmount,
+		Amount:          midMarketAmount,
+	}
+	matched := impl.match(clientID, currencyFrom, currencyTo)
+	if matched.isZero() {
+		return
+	}
+	one := decimal.NewFromInt(1)
+	// the service buys currency_from and sells currency_to, both spreads are against the client
+	afterSpread := midMarketAmount.Mul(one.Sub(matched.buySpread)).Mul(one.Sub(matched.sellSpread))
+	result.SpreadAmount = midMarketAmount.Sub(afterSpread)
+	result.MarkupAmount = afterSpread.Mul(matched.markupPercent)
+	result.FeeAmount = matched.fixedFee
+	result.Amount = afterSpread.Sub(result.MarkupAmount).Sub(result.FeeAmount)
+	if result.Amount.IsNegative() {
+		impl.deps.Logger.WithField("client", clientID).WithField("amount", midMarketAmount).Debug(ctx, "amount doesn't cover the fees")
+		result = capCharges(result)
+	}
+	result.Priced = true
+	return
+}
+
+// capCharges charges no more than the mid-market amount, the fee and then the markup are cut down to what's left of it
+// so that the breakdown still adds up with a zero Amount
+func capCharges(breakdown Breakdown) Breakdown {
+	remaining := breakdown.MidMarketAmount
+	for _, charge := range []*decimal.Decimal{&breakdown.SpreadAmount, &breakdown.MarkupAmount, &breakdown.FeeAmount} {
+		*charge = decimal.Min(*charge, remaining)
+		remaining = remaining.Sub(*charge)
+	}
+	breakdown.Amount = remaining
+	return breakdown
+}
+
+func (impl *pricerImpl) match(clientID, currencyFrom, currencyTo string) rule {
+	if impl.trustClientID && len(clientID) > 0 {
+		if clientRule, ok := impl.clientRules[strings.ToLower(clientID)]; ok {
+			return clientRule
+		}
+	}
+	if pairRule, ok := impl.pairRules[strings.ToLower(currencyFrom+"_"+currencyTo)]; ok {
+		return pairRule
+	}
+	return impl.defaultRule
+}
+
+// rule converts the configuration at key, negative charges would pay the client and are rejected
+func (config ruleConfig) rule(key string) (result rule, err error) {
+	for field, value := range map[string]float64{
+		"buySpreadBps":  config.BuySpreadBps,
+		"sellSpreadBps": config.SellSpreadBps,
+		"markupPercent": config.MarkupPercent,
+		"fixedFee":      config.FixedFee,
+	} {
+		if value < 0 {
+			err = fmt.Errorf("%s.%s can't be negative, got %v", key, field, value)
+			return
+		}
+	}
+	result = rule{
+		buySpread:     decimal.NewFromFloat(config.BuySpreadBps).Div(decimal.NewFromInt(basisPointsInOne)),
+		sellSpread:    decimal.NewFromFloat(config.SellSpreadBps).Div(decimal.NewFromInt(basisPointsInOne)),
+		markupPercent: decimal.NewFromFloat(config.MarkupPercent).Div(decimal.NewFromInt(percentsInOne)),
+		fixedFee:      decimal.NewFromFloat(config.FixedFee),
+	}
+	return
+}
+
+func (r rule) isZero() bool {
+	return r.buySpread.IsZero() && r.sellSpread.IsZero() && r.markupPercent.IsZero() && r.fixedFee.IsZero()
+}
@@ -37,7 +37,101 @@ func CreateCurrencyRateService(deps currencyRateServiceImplDeps) currencyconvert
 func (impl *currencyRateServiceImpl) Convert(ctx context.Context, req *currencyconverter.ConvertRequest) (res *currencyconverter.ConvertResponse, err error) {
 	if err = impl.deps.Validations.ValidateGetCurrencyRateRequest(ctx, req); err != nil {
 		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
 	}
 
 	return impl.deps.Controller.Convert(ctx, req)
 }
+
+// BatchConvert converts only the valid items, invalid ones are reported as per-item errors in their original positions.
+// The rates aren't read when no item is valid, the response has no correctness time then.
+func (impl *currencyRateServiceImpl) BatchConvert(ctx context.Context, req *currencyconverter.BatchConvertRequest) (res *currencyconverter.BatchConvertResponse, err error) {
+	if err = impl.deps.Validations.ValidateBatchConvertRequest(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	itemErrors := make(map[int]error)
+	validItems := make([]*currencyconverter.ConvertRequest, 0, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		if itemErr := impl.deps.Validations.ValidateGetCurrencyRateRequest(ctx, item); itemErr != nil {
+			itemErrors[i] = itemErr
+			continue
+		}
+		validItems = append(validItems, item)
+	}
+	if len(itemErrors) == 0 {
+		return impl.deps.Controller.BatchConvert(ctx, req)
+	}
+
+	var converted *currencyconverter.BatchConvertResponse
+	if len(validItems) > 0 {
+		if converted, err = impl.deps.Controller.BatchConvert(ctx, &currencyconverter.BatchConvertRequest{
+			Items: validItems,
+			AsOf:  req.GetAsOf(),
+		}); err != nil {
+			return
+		}
+	}
+	res = &currencyconverter.BatchConvertResponse{
+		Results:         make([]*currencyconverter.BatchConvertResult, 0, len(req.GetItems())),
+		CorrectnessTime: converted.GetCorrectnessTime(),
+	}
+	convertedResults := converted.GetResults()
+	for i := range req.GetItems() {
+		if itemErr, invalid := itemErrors[i]; invalid {
+			res.Results = append(res.Results, controllers.BatchConvertError(itemErr))
+			continue
+		}
+		res.Results = append(res.Results, convertedResults[0])
+		convertedResults = convertedResults[1:]
+	}
+	return
+}
+
+func (impl *currencyRateServiceImpl) WatchRates(req *currencyconverter.WatchRatesRequest, stream currencyconverter.CurrencyConverter_WatchRatesServer) (err error) {
+	if err = impl.deps.Validations.ValidateWatchRatesRequest(stream.Context(), req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(stream.Context(), "validation failed")
+		return
+	}
+	return impl.deps.Controller.WatchRates(req, stream)
+}
+
+func (impl *currencyRateServiceImpl) ListCurrencies(ctx context.Context, req *currencyconverter.ListCurrenciesRequest) (res *currencyconverter.ListCurrenciesResponse, err error) {
+	if err = impl.deps.Validations.ValidateListCurrenciesRequest(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	return impl.deps.Controller.ListCurrencies(ctx, req)
+}
+
+func (impl *currencyRateServiceImpl) GetRate(ctx context.Context, req *currencyconverter.GetRateRequest) (res *currencyconverter.GetRateResponse, err error) {
+	if err = impl.deps.Validations.ValidateGetRateRequest(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	return impl.deps.Controller.GetRate(ctx, req)
+}
+
+func (impl *currencyRateServiceImpl) GetRateHistory(ctx context.Context, req *currencyconverter.GetRateHistoryRequest) (res *currencyconverter.GetRateHistoryResponse, err error) {
+	if err = impl.deps.Validations.ValidateGetRateHistoryRequest(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	return impl.deps.Controller.GetRateHistory(ctx, req)
+}
+
+func (impl *currencyRateServiceImpl) CreateQuote(ctx context.Context, req *currencyconverter.CreateQuoteRequest) (res *currencyconverter.Quote, err error) {
+	if err = impl.deps.Validations.ValidateCreateQuoteRequest(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	return impl.deps.Controller.CreateQuote(ctx, req)
+}
+
+func (impl *currencyRateServiceImpl) ConvertWithQuote(ctx context.Context, req *currencyconverter.ConvertWithQuoteRequest) (res *currencyconverter.ConvertResponse, err error) {
+	if err = impl.deps.Validations.ValidateConvertWithQuoteRequest(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	return impl.deps.Controller.ConvertWithQuote(ctx, req)
+}
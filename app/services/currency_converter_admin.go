@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+
+	currencyconverter "github.com/bevgene/go-currency-rate/api"
+	"github.com/bevgene/go-currency-rate/app/controllers"
+	"github.com/bevgene/go-currency-rate/app/validations"
+
+	"github.com/go-masonry/mortar/interfaces/log"
+	"go.uber.org/fx"
+)
+
+type (
+	adminServiceImplDeps struct {
+		fx.In
+
+		Logger      log.Logger
+		Validations validations.AdminValidations
+		Controller  controllers.AdminController
+	}
+
+	adminServiceImpl struct {
+		currencyconverter.UnimplementedCurrencyConverterAdminServer
+		deps adminServiceImplDeps
+	}
+)
+
+func CreateAdminService(deps adminServiceImplDeps) currencyconverter.CurrencyConverterAdminServer {
+	return &adminServiceImpl{
+		deps: deps,
+	}
+}
+
+func (impl *adminServiceImpl) RefreshRates(ctx context.Context, req *currencyconverter.RefreshRatesRequest) (res *currencyconverter.RefreshRatesResponse, err error) {
+	if err = impl.deps.Validations.ValidateRefreshRatesRequest(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	return impl.deps.Controller.RefreshRates(ctx, req)
+}
+
+func (impl *adminServiceImpl) BackfillRates(ctx context.Context, req *currencyconverter.BackfillRatesRequest) (res *currencyconverter.BackfillRatesResponse, err error) {
+	if err = impl.deps.Validations.ValidateBackfillRatesRequest(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	return impl.deps.Controller.BackfillRates(ctx, req)
+}
+
+func (impl *adminServiceImpl) GetSchedule(ctx context.Context, req *currencyconverter.GetScheduleRequest) (res *currencyconverter.Schedule, err error) {
+	if err = impl.deps.Validations.ValidateGetScheduleRequest(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	return impl.deps.Controller.GetSchedule(ctx, req)
+}
+
+func (impl *adminServiceImpl) PauseSchedule(ctx context.Context, req *currencyconverter.ScheduleSignalRequest) (res *currencyconverter.ScheduleSignalResponse, err error) {
+	if err = impl.deps.Validations.ValidateScheduleSignalRequest(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	return impl.deps.Controller.PauseSchedule(ctx, req)
+}
+
+func (impl *adminServiceImpl) ResumeSchedule(ctx context.Context, req *currencyconverter.ScheduleSignalRequest) (res *currencyconverter.ScheduleSignalResponse, err error) {
+	if err = impl.deps.Validations.ValidateScheduleSignalRequest(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	return impl.deps.Controller.ResumeSchedule(ctx, req)
+}
+
+func (impl *adminServiceImpl) TriggerSchedule(ctx context.Context, req *currencyconverter.ScheduleSignalRequest) (res *currencyconverter.ScheduleSignalResponse, err error) {
+	if err = impl.deps.Validations.ValidateScheduleSignalRequest(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	return impl.deps.Controller.TriggerSchedule(ctx, req)
+}
+
+func (impl *adminServiceImpl) UpdateSchedule(ctx context.Context, req *currencyconverter.UpdateScheduleRequest) (res *currencyconverter.ScheduleSignalResponse, err error) {
+	if err = impl.deps.Validations.ValidateUpdateScheduleRequest(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	return impl.deps.Controller.UpdateSchedule(ctx, req)
+}
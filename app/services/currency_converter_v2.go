@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+
+	currencyconverterv2 "github.com/bevgene/go-currency-rate/api/v2"
+	"github.com/bevgene/go-currency-rate/app/controllers"
+	"github.com/bevgene/go-currency-rate/app/validations"
+	"github.com/go-masonry/mortar/interfaces/log"
+	"go.uber.org/fx"
+)
+
+type (
+	currencyRateServiceV2ImplDeps struct {
+		fx.In
+
+		Logger      log.Logger
+		Validations validations.CurrencyRateValidations
+		Controller  controllers.CurrencyRateControllerV2
+	}
+
+	currencyRateServiceV2Impl struct {
+		currencyconverterv2.UnimplementedCurrencyConverterServer
+		deps currencyRateServiceV2ImplDeps
+	}
+)
+
+func CreateCurrencyRateServiceV2(deps currencyRateServiceV2ImplDeps) currencyconverterv2.CurrencyConverterServer {
+	return &currencyRateServiceV2Impl{
+		deps: deps,
+	}
+}
+
+func (impl *currencyRateServiceV2Impl) Convert(ctx context.Context, req *currencyconverterv2.ConvertRequest) (res *currencyconverterv2.ConvertResponse, err error) {
+	if err = impl.deps.Validations.ValidateConvertRequestV2(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	return impl.deps.Controller.Convert(ctx, req)
+}
+
+func (impl *currencyRateServiceV2Impl) GetRate(ctx context.Context, req *currencyconverterv2.GetRateRequest) (res *currencyconverterv2.GetRateResponse, err error) {
+	if err = impl.deps.Validations.ValidateGetRateRequestV2(ctx, req); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", req).Error(ctx, "validation failed")
+		return
+	}
+	return impl.deps.Controller.GetRate(ctx, req)
+}
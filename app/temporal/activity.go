@@ -3,16 +3,23 @@ package temporal
 import (
 	"context"
 	"github.com/bevgene/go-currency-rate/app/clients"
+	"github.com/bevgene/go-currency-rate/app/data"
 	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.temporal.io/sdk/temporal"
 	"go.uber.org/fx"
+	"time"
 )
 
 type (
 	activityDeps struct {
 		fx.In
 
-		ExchangeClient  clients.ExchangeClient
-		LazyMongoClient *clients.LazyMongoClient
+		ExchangeClient   clients.ExchangeClient
+		LazyMongoClient  *clients.LazyMongoClient
+		RatesBroadcaster data.RatesBroadcaster
+		CurrencyRateDao  data.CurrencyRateDao
 	}
 
 	ExchangeActivities struct {
@@ -26,10 +33,111 @@ func CreateActivities(deps activityDeps) *ExchangeActivities {
 	}
 }
 
-func (impl *ExchangeActivities) GetRates(ctx context.Context) (result *model.ExchangeRatesModel, err error) {
-	return impl.deps.ExchangeClient.GetRates(ctx)
+// GetRates fetches the rates from a single provider, the workflow falls back to the next provider when it fails.
+// Provider errors are returned as ApplicationErrors of the same type, only transient ones are retried.
+func (impl *ExchangeActivities) GetRates(ctx context.Context, provider string) (result *model.ExchangeRatesModel, err error) {
+	if result, err = impl.deps.ExchangeClient.GetRates(ctx, provider); err != nil {
+		err = applicationError(err)
+	}
+	return
+}
+
+// GetHistoricalRates fetches the rates of a past date from a single provider, errors are handled the same as by GetRates
+func (impl *ExchangeActivities) GetHistoricalRates(ctx context.Context, provider string, date time.Time) (result *model.ExchangeRatesModel, err error) {
+	if result, err = impl.deps.ExchangeClient.GetHistoricalRates(ctx, provider, date); err != nil {
+		err = applicationError(err)
+	}
+	return
+}
+
+// FindMissingRates returns the slots of the request range that have no fiat rates stored
+func (impl *ExchangeActivities) FindMissingRates(ctx context.Context, request model.BackfillRequest) (slots []time.Time, err error) {
+	var existing []time.Time
+	if existing, err = impl.deps.LazyMongoClient.Client.GetRateDocumentTimes(ctx, request.Start, request.End); err != nil {
+		return
+	}
+	if slots, err = request.MissingSlots(existing); err != nil {
+		err = temporal.NewNonRetryableApplicationError(err.Error(), "InvalidRequest", err)
+	}
+	return
+}
+
+// AddHistoricalRates stores a backfilled document without publishing it, it returns false when a document of the same
+// creation time already exists, e.g. when an interrupted backfill is resumed
+func (impl *ExchangeActivities) AddHistoricalRates(ctx context.Context, doc *model.ExchangeRateDocument) (added bool, err error) {
+	if err = impl.deps.LazyMongoClient.Client.AddRateDocument(ctx, doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = nil
+		}
+		return
+	}
+	added = true
+	return
+}
+
+// ValidateRates returns the reasons the document shouldn't become the latest rates, it's compared to the latest stored one
+func (impl *ExchangeActivities) ValidateRates(ctx context.Context, doc *model.ExchangeRateDocument, maxChangePercent float64) (violations []string, err error) {
+	var previous *model.ExchangeRateDocument
+	if previous, err = impl.deps.LazyMongoClient.Client.GetLatestAssetClassRateDocument(ctx, doc.AssetClass); err != nil {
+		return
+	}
+	violations = doc.Validate(previous, decimal.NewFromFloat(maxChangePercent))
+	return
+}
+
+// ValidateHistoricalRates returns the reasons a backfilled document shouldn't be stored, it's compared to the stored
+// document right before it
+func (impl *ExchangeActivities) ValidateHistoricalRates(ctx context.Context, doc *model.ExchangeRateDocument, maxChangePercent float64) (violations []string, err error) {
+	var previous *model.ExchangeRateDocument
+	if previous, err = impl.deps.LazyMongoClient.Client.GetRateDocumentAt(ctx, doc.CreatedAt); err != nil {
+		return
+	}
+	violations = doc.ValidateHistorical(previous, decimal.NewFromFloat(maxChangePercent))
+	return
 }
 
-func (impl *ExchangeActivities) UpdateRates(ctx context.Context, doc *model.ExchangeRateDocument) error {
-	return impl.deps.LazyMongoClient.Client.AddRateDocument(ctx, doc)
+// QuarantineRates stores a document that failed validation for review
+func (impl *ExchangeActivities) QuarantineRates(ctx context.Context, doc *model.ExchangeRateDocument, violations []string) error {
+	return impl.deps.LazyMongoClient.Client.AddQuarantinedRatesDocument(ctx, &model.QuarantinedRatesDocument{
+		ExchangeRateDocument: *doc,
+		Violations:           violations,
+		QuarantinedAt:        time.Now().UTC(),
+	})
+}
+
+// UpdateRates stores and publishes the latest rates, rates of the same creation time were stored by a previous attempt
+// and there is nothing left to do
+func (impl *ExchangeActivities) UpdateRates(ctx context.Context, doc *model.ExchangeRateDocument) (err error) {
+	if err = impl.deps.LazyMongoClient.Client.AddRateDocument(ctx, doc); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			err = nil
+		}
+		return
+	}
+	// subscribers get the rates of all the asset classes, whichever class was updated
+	var published *model.ExchangeRateDocument
+	var publishErr error
+	if len(doc.AssetClass) == 0 || doc.AssetClass == model.AssetClassFiat {
+		published, publishErr = impl.deps.CurrencyRateDao.MergeAssetClasses(ctx, doc)
+	} else if published, publishErr = impl.deps.LazyMongoClient.Client.GetLatestRateDocument(ctx); publishErr == nil {
+		// the latest fiat rates are read from mongo, the dao might serve cached ones without the new class rates
+		published, publishErr = impl.deps.CurrencyRateDao.MergeAssetClasses(ctx, published)
+	}
+	if publishErr != nil || published == nil {
+		// the rates are stored, subscribers will get them with the next update
+		return
+	}
+	impl.deps.RatesBroadcaster.Publish(ctx, published)
+	return
+}
+
+func applicationError(err error) error {
+	providerErr, ok := clients.AsProviderError(err)
+	if !ok {
+		return err
+	}
+	if providerErr.Retryable() {
+		return temporal.NewApplicationErrorWithCause(providerErr.Error(), string(providerErr.Type), err)
+	}
+	return temporal.NewNonRetryableApplicationError(providerErr.Error(), string(providerErr.Type), err)
 }
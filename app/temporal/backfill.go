@@ -0,0 +1,250 @@
+package temporal
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bevgene/go-currency-rate/app/clients"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/go-masonry/mortar/interfaces/cfg"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"go.uber.org/fx"
+	"time"
+)
+
+type (
+	backfillRatesWorkflowDeps struct {
+		fx.In
+
+		Config             cfg.Config
+		ExchangeClient     clients.ExchangeClient
+		ExchangeActivities *ExchangeActivities
+	}
+
+	BackfillRatesWorkflow struct {
+		deps backfillRatesWorkflowDeps
+	}
+
+	// BackfillResult sums up a single run, a backfill that continued as new reports its last run only
+	BackfillResult struct {
+		// Filled is the number of stored documents, one per day at most
+		Filled int
+		// Skipped holds the days none of the providers had rates of
+		Skipped []time.Time
+		// Quarantined holds the days whose rates failed validation, their slots keep the previous rates
+		Quarantined []time.Time
+	}
+
+	backfillSettings struct {
+		Providers        []string
+		ProviderAttempts int32
+		CanonicalBase    string
+		MaxChangePercent float64
+		// RequestInterval is the pause between two provider requests, it keeps the backfill within the provider quotas
+		RequestInterval time.Duration
+		// SlotsPerRun bounds the workflow history, the rest of the range is filled by a new run
+		SlotsPerRun int
+	}
+)
+
+const (
+	defaultBackfillSlotsPerRun = 100
+)
+
+func CreateBackfillRatesWorkflow(deps backfillRatesWorkflowDeps) *BackfillRatesWorkflow {
+	return &BackfillRatesWorkflow{
+		deps: deps,
+	}
+}
+
+// Backfill stores historical rates for the days of the range that have missing slots, e.g. the hours the service was down.
+// Providers serve end of day rates, so a single document is stored per day at the time its rates were published. The
+// slots of the day before that time keep the rates published earlier, as_of lookups never get rates from their future.
+// The gaps are looked up in the database, so a backfill that failed is resumed by running it again over the same range.
+func (impl *BackfillRatesWorkflow) Backfill(ctx workflow.Context, request model.BackfillRequest) (result BackfillResult, err error) {
+	workflow.GetLogger(ctx).Info("Backfill workflow started.", "Start", request.Start, "End", request.End)
+	activityOptions := workflow.ActivityOptions{
+		StartToCloseTimeout:    time.Minute,
+		ScheduleToStartTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    10 * time.Second,
+			MaximumAttempts:    storageAttempts,
+		},
+	}
+	ctx1 := workflow.WithActivityOptions(ctx, activityOptions)
+
+	var settings backfillSettings
+	if err = workflow.SideEffect(ctx, func(workflow.Context) interface{} {
+		return impl.settings()
+	}).Get(&settings); err != nil {
+		workflow.GetLogger(ctx).Error("Backfill failed.", "Error", err)
+		return
+	}
+	if len(settings.Providers) == 0 {
+		err = temporal.NewNonRetryableApplicationError("none of the providers serves historical rates", "NoHistoricalProviders", nil)
+		workflow.GetLogger(ctx).Error("Backfill failed.", "Error", err)
+		return
+	}
+	providerOptions := activityOptions
+	providerOptions.RetryPolicy = &temporal.RetryPolicy{
+		InitialInterval:    time.Second,
+		BackoffCoefficient: 2,
+		MaximumInterval:    10 * time.Second,
+		MaximumAttempts:    settings.ProviderAttempts,
+	}
+	providerCtx := workflow.WithActivityOptions(ctx, providerOptions)
+
+	var slots []time.Time
+	if err = workflow.ExecuteActivity(ctx1, impl.deps.ExchangeActivities.FindMissingRates, request).Get(ctx, &slots); err != nil {
+		workflow.GetLogger(ctx).Error("Backfill failed.", "Error", err)
+		return
+	}
+	var next *model.BackfillRequest
+	if len(slots) > settings.SlotsPerRun {
+		remaining := request
+		remaining.Start = slots[settings.SlotsPerRun]
+		next = &remaining
+		slots = slots[:settings.SlotsPerRun]
+	}
+	workflow.GetLogger(ctx).Info("Missing slots found.", "Slots", len(slots), "Continues", next != nil)
+
+	fetcher := &historicalRatesFetcher{
+		workflow:  impl,
+		settings:  settings,
+		exhausted: make(map[string]bool),
+	}
+	for len(slots) > 0 {
+		day := slots[0].Truncate(24 * time.Hour)
+		daySlots := slots
+		for i, slot := range slots {
+			if !slot.Truncate(24 * time.Hour).Equal(day) {
+				daySlots = slots[:i]
+				break
+			}
+		}
+		slots = slots[len(daySlots):]
+
+		var document *model.ExchangeRateDocument
+		if document, err = fetcher.fetch(ctx, providerCtx, day); err != nil {
+			if fetcher.allExhausted() {
+				// the filled slots are kept, running the backfill again once the quotas reset resumes it
+				workflow.GetLogger(ctx).Error("Backfill stopped, provider quotas are exhausted.", "Day", day, "Error", err)
+				return
+			}
+			workflow.GetLogger(ctx).Warn("No rates of the day, it's skipped.", "Day", day, "Error", err)
+			result.Skipped = append(result.Skipped, day)
+			err = nil
+			continue
+		}
+		document.CreatedAt = publishedAt(document, day)
+		if document.CreatedAt.Before(request.Start) || !document.CreatedAt.Before(request.End) {
+			workflow.GetLogger(ctx).Info("Rates of the day are published outside of the range, the slots keep the previous rates.",
+				"Day", day, "PublishedAt", document.CreatedAt, "Slots", len(daySlots))
+			continue
+		}
+		var violations []string
+		if err = workflow.ExecuteActivity(ctx1, impl.deps.ExchangeActivities.ValidateHistoricalRates, document, settings.MaxChangePercent).Get(ctx, &violations); err != nil {
+			workflow.GetLogger(ctx).Error("Backfill failed.", "Day", day, "Error", err)
+			return
+		}
+		if len(violations) > 0 {
+			workflow.GetLogger(ctx).Warn("Rates of the day failed validation, they're quarantined.", "Day", day, "Violations", violations)
+			if err = workflow.ExecuteActivity(ctx1, impl.deps.ExchangeActivities.QuarantineRates, document, violations).Get(ctx, nil); err != nil {
+				workflow.GetLogger(ctx).Error("Backfill failed.", "Day", day, "Error", err)
+				return
+			}
+			result.Quarantined = append(result.Quarantined, day)
+			continue
+		}
+		var added bool
+		if err = workflow.ExecuteActivity(ctx1, impl.deps.ExchangeActivities.AddHistoricalRates, document).Get(ctx, &added); err != nil {
+			workflow.GetLogger(ctx).Error("Backfill failed.", "Day", day, "Error", err)
+			return
+		}
+		if added {
+			result.Filled++
+		}
+	}
+	workflow.GetLogger(ctx).Info("Backfill run finished.", "Filled", result.Filled, "Skipped", len(result.Skipped),
+		"Quarantined", len(result.Quarantined))
+	if next != nil {
+		err = workflow.NewContinueAsNewError(ctx, impl.Backfill, *next)
+	}
+	return
+}
+
+// publishedAt returns the publication time of the rates of the day, the end of the day when the provider timestamp
+// doesn't fall within it
+func publishedAt(document *model.ExchangeRateDocument, day time.Time) time.Time {
+	end := day.Add(24 * time.Hour)
+	if document.CreatedAt.Before(day) || !document.CreatedAt.Before(end) {
+		return end.Add(-time.Second)
+	}
+	return document.CreatedAt
+}
+
+func (impl *BackfillRatesWorkflow) settings() (result backfillSettings) {
+	result = backfillSettings{
+		Providers:        impl.deps.ExchangeClient.HistoricalProviders(),
+		ProviderAttempts: impl.deps.Config.Get(providerAttemptsKey).Int32(),
+		CanonicalBase:    impl.deps.Config.Get(canonicalBaseKey).String(),
+		MaxChangePercent: impl.deps.Config.Get(maxChangePercentKey).Float64(),
+		RequestInterval:  impl.deps.Config.Get(backfillRequestIntervalKey).Duration(),
+		SlotsPerRun:      impl.deps.Config.Get(backfillSlotsPerRunKey).Int(),
+	}
+	if result.ProviderAttempts <= 0 {
+		result.ProviderAttempts = defaultProviderAttempts
+	}
+	if result.SlotsPerRun <= 0 {
+		result.SlotsPerRun = defaultBackfillSlotsPerRun
+	}
+	return
+}
+
+// historicalRatesFetcher fails over between the historical providers and paces the requests of a single run
+type historicalRatesFetcher struct {
+	workflow *BackfillRatesWorkflow
+	settings backfillSettings
+	requests int
+	// exhausted holds the providers whose quota ran out, they're not requested again within the run
+	exhausted map[string]bool
+}
+
+// fetch returns the rates of the day rebased to the canonical base, from the first provider that has them
+func (fetcher *historicalRatesFetcher) fetch(ctx, providerCtx workflow.Context, day time.Time) (result *model.ExchangeRateDocument, err error) {
+	err = fmt.Errorf("no provider left")
+	for _, name := range fetcher.settings.Providers {
+		if fetcher.exhausted[name] {
+			continue
+		}
+		if fetcher.requests > 0 && fetcher.settings.RequestInterval > 0 {
+			if err = workflow.Sleep(ctx, fetcher.settings.RequestInterval); err != nil {
+				return
+			}
+		}
+		fetcher.requests++
+		var rates model.ExchangeRatesModel
+		if err = workflow.ExecuteActivity(providerCtx, fetcher.workflow.deps.ExchangeActivities.GetHistoricalRates, name, day).Get(ctx, &rates); err != nil {
+			var applicationErr *temporal.ApplicationError
+			if errors.As(err, &applicationErr) && applicationErr.Type() == string(clients.ErrQuotaExceeded) {
+				fetcher.exhausted[name] = true
+			}
+			workflow.GetLogger(ctx).Warn("Provider failed, falling back to the next one.", "Provider", name, "Day", day, "Error", err)
+			continue
+		}
+		if result, err = canonicalDocument(rates, fetcher.settings.CanonicalBase); err != nil {
+			workflow.GetLogger(ctx).Warn("Provider rates can't be rebased, falling back to the next one.", "Provider", name, "Error", err)
+			continue
+		}
+		result.Provider = name
+		result.AssetClass = model.AssetClassFiat
+		return
+	}
+	return
+}
+
+func (fetcher *historicalRatesFetcher) allExhausted() bool {
+	return len(fetcher.exhausted) == len(fetcher.settings.Providers)
+}
@@ -1,8 +1,21 @@
 package temporal
 
 const (
-	queueNameKey            = "exchangerate.temporal.queue"
-	workflowNameKey         = "exchangerate.temporal.workflowName"
-	maxConcurrentWorkersKey = "exchangerate.temporal.maxConcurrentWorkers"
-	cronScheduleKey         = "exchangerate.temporal.cronSchedule"
+	queueNameKey               = "exchangerate.temporal.queue"
+	workflowNameKey            = "exchangerate.temporal.workflowName"
+	maxConcurrentWorkersKey    = "exchangerate.temporal.maxConcurrentWorkers"
+	cronScheduleKey            = "exchangerate.temporal.cronSchedule"
+	providerAttemptsKey        = "exchangerate.temporal.providerAttempts"
+	consensusKey               = "exchangerate.consensus"
+	maxChangePercentKey        = "exchangerate.validation.maxChangePercent"
+	canonicalBaseKey           = "exchangerate.canonicalBase"
+	backfillRequestIntervalKey = "exchangerate.backfill.requestInterval"
+	backfillSlotsPerRunKey     = "exchangerate.backfill.slotsPerRun"
+
+	// ConsensusProvider is the provider of documents aggregated from several providers
+	ConsensusProvider = "consensus"
+
+	defaultProviderAttempts = 3
+	// storageAttempts bounds the retries of the database activities, a run never waits on the database forever
+	storageAttempts = 5
 )
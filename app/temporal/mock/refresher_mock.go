@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: refresher.go
+
+// Package mock_temporal is a generated GoMock package.
+package mock_temporal
+
+import (
+	context "context"
+	model "github.com/bevgene/go-currency-rate/app/model"
+	temporal "github.com/bevgene/go-currency-rate/app/temporal"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockRatesRefresher is a mock of RatesRefresher interface
+type MockRatesRefresher struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatesRefresherMockRecorder
+}
+
+// MockRatesRefresherMockRecorder is the mock recorder for MockRatesRefresher
+type MockRatesRefresherMockRecorder struct {
+	mock *MockRatesRefresher
+}
+
+// NewMockRatesRefresher creates a new mock instance
+func NewMockRatesRefresher(ctrl *gomock.Controller) *MockRatesRefresher {
+	mock := &MockRatesRefresher{ctrl: ctrl}
+	mock.recorder = &MockRatesRefresherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRatesRefresher) EXPECT() *MockRatesRefresherMockRecorder {
+	return m.recorder
+}
+
+// Refresh mocks base method
+func (m *MockRatesRefresher) Refresh(ctx context.Context, assetClass model.AssetClass, wait bool) (temporal.RefreshResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, assetClass, wait)
+	ret0, _ := ret[0].(temporal.RefreshResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh
+func (mr *MockRatesRefresherMockRecorder) Refresh(ctx, assetClass, wait interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockRatesRefresher)(nil).Refresh), ctx, assetClass, wait)
+}
+
+// Backfill mocks base method
+func (m *MockRatesRefresher) Backfill(ctx context.Context, request model.BackfillRequest) (temporal.RefreshResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backfill", ctx, request)
+	ret0, _ := ret[0].(temporal.RefreshResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backfill indicates an expected call of Backfill
+func (mr *MockRatesRefresherMockRecorder) Backfill(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backfill", reflect.TypeOf((*MockRatesRefresher)(nil).Backfill), ctx, request)
+}
@@ -0,0 +1,121 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: schedules.go
+
+// Package mock_temporal is a generated GoMock package.
+package mock_temporal
+
+import (
+	context "context"
+	model "github.com/bevgene/go-currency-rate/app/model"
+	temporal "github.com/bevgene/go-currency-rate/app/temporal"
+	gomock "github.com/golang/mock/gomock"
+	reflect "reflect"
+)
+
+// MockScheduleManager is a mock of ScheduleManager interface
+type MockScheduleManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduleManagerMockRecorder
+}
+
+// MockScheduleManagerMockRecorder is the mock recorder for MockScheduleManager
+type MockScheduleManagerMockRecorder struct {
+	mock *MockScheduleManager
+}
+
+// NewMockScheduleManager creates a new mock instance
+func NewMockScheduleManager(ctrl *gomock.Controller) *MockScheduleManager {
+	mock := &MockScheduleManager{ctrl: ctrl}
+	mock.recorder = &MockScheduleManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockScheduleManager) EXPECT() *MockScheduleManagerMockRecorder {
+	return m.recorder
+}
+
+// WorkflowID mocks base method
+func (m *MockScheduleManager) WorkflowID(assetClass model.AssetClass) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowID", assetClass)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// WorkflowID indicates an expected call of WorkflowID
+func (mr *MockScheduleManagerMockRecorder) WorkflowID(assetClass interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowID", reflect.TypeOf((*MockScheduleManager)(nil).WorkflowID), assetClass)
+}
+
+// Get mocks base method
+func (m *MockScheduleManager) Get(ctx context.Context, assetClass model.AssetClass) (temporal.ScheduleState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, assetClass)
+	ret0, _ := ret[0].(temporal.ScheduleState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockScheduleManagerMockRecorder) Get(ctx, assetClass interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockScheduleManager)(nil).Get), ctx, assetClass)
+}
+
+// Pause mocks base method
+func (m *MockScheduleManager) Pause(ctx context.Context, assetClass model.AssetClass) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pause", ctx, assetClass)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pause indicates an expected call of Pause
+func (mr *MockScheduleManagerMockRecorder) Pause(ctx, assetClass interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockScheduleManager)(nil).Pause), ctx, assetClass)
+}
+
+// Resume mocks base method
+func (m *MockScheduleManager) Resume(ctx context.Context, assetClass model.AssetClass) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", ctx, assetClass)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resume indicates an expected call of Resume
+func (mr *MockScheduleManagerMockRecorder) Resume(ctx, assetClass interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockScheduleManager)(nil).Resume), ctx, assetClass)
+}
+
+// Trigger mocks base method
+func (m *MockScheduleManager) Trigger(ctx context.Context, assetClass model.AssetClass) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trigger", ctx, assetClass)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Trigger indicates an expected call of Trigger
+func (mr *MockScheduleManagerMockRecorder) Trigger(ctx, assetClass interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trigger", reflect.TypeOf((*MockScheduleManager)(nil).Trigger), ctx, assetClass)
+}
+
+// Update mocks base method
+func (m *MockScheduleManager) Update(ctx context.Context, assetClass model.AssetClass, cronSchedule string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, assetClass, cronSchedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update
+func (mr *MockScheduleManagerMockRecorder) Update(ctx, assetClass, cronSchedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockScheduleManager)(nil).Update), ctx, assetClass, cronSchedule)
+}
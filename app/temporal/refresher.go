@@ -0,0 +1,94 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"github.com/bevgene/go-currency-rate/app/clients"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/go-masonry/mortar/interfaces/cfg"
+	"github.com/go-masonry/mortar/interfaces/log"
+	"github.com/pborman/uuid"
+	"go.temporal.io/sdk/client"
+	"go.uber.org/fx"
+	"time"
+)
+
+//go:generate mockgen -source=refresher.go -destination=mock/refresher_mock.go
+
+type (
+	// RatesRefresher runs the update rates workflow on demand, outside of the cron schedule
+	RatesRefresher interface {
+		Refresh(ctx context.Context, assetClass model.AssetClass, wait bool) (RefreshResult, error)
+		// Backfill starts the backfill workflow without waiting for it, a backfill of the same range that is still
+		// running is reported as a serviceerror.WorkflowExecutionAlreadyStarted
+		Backfill(ctx context.Context, request model.BackfillRequest) (RefreshResult, error)
+	}
+
+	RefreshResult struct {
+		WorkflowID string
+		RunID      string
+		// CreatedAt is the creation time of the stored rates, zero when not waiting for the workflow
+		CreatedAt time.Time
+	}
+
+	ratesRefresherDeps struct {
+		fx.In
+
+		Config                cfg.Config
+		Logger                log.Logger
+		TemporalClient        *clients.LazyClient
+		UpdateRatesWorkflow   *UpdateRatesWorkflow
+		BackfillRatesWorkflow *BackfillRatesWorkflow
+	}
+
+	ratesRefresherImpl struct {
+		deps ratesRefresherDeps
+	}
+)
+
+func CreateRatesRefresher(deps ratesRefresherDeps) RatesRefresher {
+	return &ratesRefresherImpl{
+		deps: deps,
+	}
+}
+
+func (impl *ratesRefresherImpl) Refresh(ctx context.Context, assetClass model.AssetClass, wait bool) (result RefreshResult, err error) {
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        fmt.Sprintf("refresh_%s_%s_%s", impl.deps.Config.Get(workflowNameKey).String(), assetClass, uuid.New()),
+		TaskQueue: impl.deps.Config.Get(queueNameKey).String(),
+	}
+	var workflowRun client.WorkflowRun
+	if workflowRun, err = impl.deps.TemporalClient.ExecuteWorkflow(ctx, workflowOptions, impl.deps.UpdateRatesWorkflow.UpdateRates, assetClass); err != nil {
+		impl.deps.Logger.WithError(err).WithField("asset class", assetClass).Error(ctx, "failed starting refresh workflow")
+		return
+	}
+	result.WorkflowID = workflowRun.GetID()
+	result.RunID = workflowRun.GetRunID()
+	impl.deps.Logger.WithField("workflow id", result.WorkflowID).WithField("run_id", result.RunID).Info(ctx, "started refresh workflow")
+	if !wait {
+		return
+	}
+	if err = workflowRun.Get(ctx, &result.CreatedAt); err != nil {
+		impl.deps.Logger.WithError(err).WithField("workflow id", result.WorkflowID).Error(ctx, "refresh workflow failed")
+	}
+	return
+}
+
+func (impl *ratesRefresherImpl) Backfill(ctx context.Context, request model.BackfillRequest) (result RefreshResult, err error) {
+	workflowOptions := client.StartWorkflowOptions{
+		// the range is part of the ID so that the same range isn't backfilled twice at the same time
+		ID:        fmt.Sprintf("backfill_%s_%d_%d", impl.deps.Config.Get(workflowNameKey).String(), request.Start.Unix(), request.End.Unix()),
+		TaskQueue: impl.deps.Config.Get(queueNameKey).String(),
+		// the workflow continues as new every slotsPerRun slots, the timeout covers all the runs
+		WorkflowExecutionTimeout: 24 * time.Hour,
+	}
+	var workflowRun client.WorkflowRun
+	if workflowRun, err = impl.deps.TemporalClient.ExecuteWorkflow(ctx, workflowOptions, impl.deps.BackfillRatesWorkflow.Backfill, request); err != nil {
+		impl.deps.Logger.WithError(err).WithField("request", request).Error(ctx, "failed starting backfill workflow")
+		return
+	}
+	result.WorkflowID = workflowRun.GetID()
+	result.RunID = workflowRun.GetRunID()
+	impl.deps.Logger.WithField("workflow id", result.WorkflowID).WithField("run_id", result.RunID).Info(ctx, "started backfill workflow")
+	return
+}
@@ -0,0 +1,185 @@
+package temporal
+
+import (
+	"fmt"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/robfig/cron"
+	"go.temporal.io/sdk/workflow"
+	"go.uber.org/fx"
+	"time"
+)
+
+type (
+	ratesSchedulerWorkflowDeps struct {
+		fx.In
+
+		UpdateRatesWorkflow *UpdateRatesWorkflow
+	}
+
+	// RatesSchedulerWorkflow runs the update rates workflow of an asset class on a cron spec. Unlike a cron workflow it
+	// outlives the service, it can be paused, resumed, triggered and have its spec changed through signals.
+	RatesSchedulerWorkflow struct {
+		deps ratesSchedulerWorkflowDeps
+	}
+
+	// ScheduleState is the state of a schedule, it's carried over when the schedule continues as new
+	ScheduleState struct {
+		AssetClass   model.AssetClass
+		CronSchedule string
+		Paused       bool
+		// NextRunAt is zero while the schedule is paused
+		NextRunAt time.Time
+		LastRunAt time.Time
+		// LastError is the error of the last run, empty when it succeeded
+		LastError string
+		// Runs counts the runs since the schedule last continued as new
+		Runs int
+	}
+)
+
+const (
+	PauseScheduleSignal   = "pause"
+	ResumeScheduleSignal  = "resume"
+	TriggerScheduleSignal = "trigger"
+	// UpdateScheduleSignal carries the new cron spec
+	UpdateScheduleSignal = "update_spec"
+	ScheduleStateQuery   = "state"
+
+	// schedulerRunsPerHistory bounds the history of the schedule workflow, it continues as new once reached
+	schedulerRunsPerHistory = 100
+)
+
+func CreateRatesSchedulerWorkflow(deps ratesSchedulerWorkflowDeps) *RatesSchedulerWorkflow {
+	return &RatesSchedulerWorkflow{
+		deps: deps,
+	}
+}
+
+// Schedule runs the update rates workflow of the state asset class as a child workflow whenever the cron spec fires or
+// the schedule is triggered. Runs never overlap, signals received during a run are handled once it's done.
+func (impl *RatesSchedulerWorkflow) Schedule(ctx workflow.Context, state ScheduleState) (err error) {
+	if err = workflow.SetQueryHandler(ctx, ScheduleStateQuery, func() (ScheduleState, error) {
+		return state, nil
+	}); err != nil {
+		return
+	}
+	workflow.GetLogger(ctx).Info("Schedule started.", "AssetClass", state.AssetClass, "CronSchedule", state.CronSchedule, "Paused", state.Paused)
+	signals := newScheduleSignals(ctx)
+	for state.Runs < schedulerRunsPerHistory {
+		timerCtx, cancelTimer := workflow.WithCancel(ctx)
+		selector := workflow.NewSelector(ctx)
+		run := false
+		state.NextRunAt = time.Time{}
+		if !state.Paused {
+			if schedule, parseErr := cron.ParseStandard(state.CronSchedule); parseErr != nil {
+				// the schedule idles until it gets a valid spec
+				state.LastError = parseErr.Error()
+			} else {
+				now := workflow.Now(ctx).UTC()
+				state.NextRunAt = schedule.Next(now)
+				selector.AddFuture(workflow.NewTimer(timerCtx, state.NextRunAt.Sub(now)), func(f workflow.Future) {
+					run = f.Get(ctx, nil) == nil
+				})
+			}
+		}
+		selector.AddReceive(ctx.Done(), func(workflow.ReceiveChannel, bool) {})
+		signals.addTo(ctx, selector, &state, &run)
+		selector.Select(ctx)
+		cancelTimer()
+		if err = ctx.Err(); err != nil {
+			workflow.GetLogger(ctx).Info("Schedule stopped.", "AssetClass", state.AssetClass)
+			return
+		}
+		if run {
+			impl.run(ctx, &state)
+		}
+	}
+	// signals that weren't handled yet would be lost by the new run
+	for run := false; signals.handlePending(ctx, &state, &run); run = false {
+		if run {
+			impl.run(ctx, &state)
+		}
+	}
+	state.Runs = 0
+	return workflow.NewContinueAsNewError(ctx, impl.Schedule, state)
+}
+
+// run executes a single update, its failure is recorded in the state and the schedule goes on
+func (impl *RatesSchedulerWorkflow) run(ctx workflow.Context, state *ScheduleState) {
+	childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID: fmt.Sprintf("%s_%d", workflow.GetInfo(ctx).WorkflowExecution.ID, workflow.Now(ctx).Unix()),
+	})
+	var createdAt time.Time
+	err := workflow.ExecuteChildWorkflow(childCtx, impl.deps.UpdateRatesWorkflow.UpdateRates, state.AssetClass).Get(ctx, &createdAt)
+	state.Runs++
+	state.LastRunAt = workflow.Now(ctx)
+	state.LastError = ""
+	if err != nil {
+		state.LastError = err.Error()
+		workflow.GetLogger(ctx).Error("Scheduled run failed.", "AssetClass", state.AssetClass, "Error", err)
+		return
+	}
+	workflow.GetLogger(ctx).Info("Scheduled run finished.", "AssetClass", state.AssetClass, "CreatedAt", createdAt)
+}
+
+type scheduleSignals struct {
+	pause   workflow.ReceiveChannel
+	resume  workflow.ReceiveChannel
+	trigger workflow.ReceiveChannel
+	update  workflow.ReceiveChannel
+}
+
+func newScheduleSignals(ctx workflow.Context) *scheduleSignals {
+	return &scheduleSignals{
+		pause:   workflow.GetSignalChannel(ctx, PauseScheduleSignal),
+		resume:  workflow.GetSignalChannel(ctx, ResumeScheduleSignal),
+		trigger: workflow.GetSignalChannel(ctx, TriggerScheduleSignal),
+		update:  workflow.GetSignalChannel(ctx, UpdateScheduleSignal),
+	}
+}
+
+func (signals *scheduleSignals) addTo(ctx workflow.Context, selector workflow.Selector, state *ScheduleState, run *bool) {
+	selector.AddReceive(signals.pause, func(c workflow.ReceiveChannel, _ bool) {
+		c.Receive(ctx, nil)
+		state.Paused = true
+	})
+	selector.AddReceive(signals.resume, func(c workflow.ReceiveChannel, _ bool) {
+		c.Receive(ctx, nil)
+		state.Paused = false
+	})
+	selector.AddReceive(signals.trigger, func(c workflow.ReceiveChannel, _ bool) {
+		c.Receive(ctx, nil)
+		*run = true
+	})
+	selector.AddReceive(signals.update, func(c workflow.ReceiveChannel, _ bool) {
+		var spec string
+		c.Receive(ctx, &spec)
+		signals.updateSpec(ctx, state, spec)
+	})
+}
+
+// handlePending applies a single pending signal, it returns false when there are none
+func (signals *scheduleSignals) handlePending(ctx workflow.Context, state *ScheduleState, run *bool) bool {
+	var spec string
+	switch {
+	case signals.pause.ReceiveAsync(nil):
+		state.Paused = true
+	case signals.resume.ReceiveAsync(nil):
+		state.Paused = false
+	case signals.update.ReceiveAsync(&spec):
+		signals.updateSpec(ctx, state, spec)
+	case signals.trigger.ReceiveAsync(nil):
+		*run = true
+	default:
+		return false
+	}
+	return true
+}
+
+func (signals *scheduleSignals) updateSpec(ctx workflow.Context, state *ScheduleState, spec string) {
+	if err := model.ValidateCronSchedule(spec); err != nil {
+		workflow.GetLogger(ctx).Warn("Cron schedule update ignored.", "AssetClass", state.AssetClass, "Error", err)
+		return
+	}
+	state.CronSchedule = spec
+}
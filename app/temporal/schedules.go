@@ -0,0 +1,87 @@
+package temporal
+
+import (
+	"context"
+	"github.com/bevgene/go-currency-rate/app/clients"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/go-masonry/mortar/interfaces/cfg"
+	"github.com/go-masonry/mortar/interfaces/log"
+	"go.temporal.io/sdk/converter"
+	"go.uber.org/fx"
+)
+
+//go:generate mockgen -source=schedules.go -destination=mock/schedules_mock.go
+
+type (
+	// ScheduleManager controls the running schedules, changes are signaled to the schedule which applies them asynchronously
+	ScheduleManager interface {
+		WorkflowID(assetClass model.AssetClass) string
+		Get(ctx context.Context, assetClass model.AssetClass) (ScheduleState, error)
+		Pause(ctx context.Context, assetClass model.AssetClass) error
+		Resume(ctx context.Context, assetClass model.AssetClass) error
+		Trigger(ctx context.Context, assetClass model.AssetClass) error
+		Update(ctx context.Context, assetClass model.AssetClass, cronSchedule string) error
+	}
+
+	scheduleManagerDeps struct {
+		fx.In
+
+		Config         cfg.Config
+		Logger         log.Logger
+		TemporalClient *clients.LazyClient
+	}
+
+	scheduleManagerImpl struct {
+		deps scheduleManagerDeps
+	}
+)
+
+func CreateScheduleManager(deps scheduleManagerDeps) ScheduleManager {
+	return &scheduleManagerImpl{
+		deps: deps,
+	}
+}
+
+func (impl *scheduleManagerImpl) WorkflowID(assetClass model.AssetClass) string {
+	return ScheduleWorkflowID(impl.deps.Config, assetClass)
+}
+
+func (impl *scheduleManagerImpl) Get(ctx context.Context, assetClass model.AssetClass) (result ScheduleState, err error) {
+	var value converter.EncodedValue
+	if value, err = impl.deps.TemporalClient.QueryWorkflow(ctx, impl.WorkflowID(assetClass), "", ScheduleStateQuery); err != nil {
+		impl.deps.Logger.WithError(err).WithField("asset class", assetClass).Error(ctx, "failed querying schedule")
+		return
+	}
+	err = value.Get(&result)
+	return
+}
+
+func (impl *scheduleManagerImpl) Pause(ctx context.Context, assetClass model.AssetClass) error {
+	return impl.signal(ctx, assetClass, PauseScheduleSignal, nil)
+}
+
+func (impl *scheduleManagerImpl) Resume(ctx context.Context, assetClass model.AssetClass) error {
+	return impl.signal(ctx, assetClass, ResumeScheduleSignal, nil)
+}
+
+func (impl *scheduleManagerImpl) Trigger(ctx context.Context, assetClass model.AssetClass) error {
+	return impl.signal(ctx, assetClass, TriggerScheduleSignal, nil)
+}
+
+// Update replaces the cron spec, an invalid spec is rejected before it reaches the schedule
+func (impl *scheduleManagerImpl) Update(ctx context.Context, assetClass model.AssetClass, cronSchedule string) (err error) {
+	if err = model.ValidateCronSchedule(cronSchedule); err != nil {
+		return
+	}
+	return impl.signal(ctx, assetClass, UpdateScheduleSignal, cronSchedule)
+}
+
+func (impl *scheduleManagerImpl) signal(ctx context.Context, assetClass model.AssetClass, signalName string, arg interface{}) (err error) {
+	workflowID := impl.WorkflowID(assetClass)
+	if err = impl.deps.TemporalClient.SignalWorkflow(ctx, workflowID, "", signalName, arg); err != nil {
+		impl.deps.Logger.WithError(err).WithField("workflow id", workflowID).WithField("signal", signalName).Error(ctx, "failed signaling schedule")
+		return
+	}
+	impl.deps.Logger.WithField("workflow id", workflowID).WithField("signal", signalName).Info(ctx, "signaled schedule")
+	return
+}
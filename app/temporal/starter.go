@@ -4,62 +4,103 @@ import (
 	"context"
 	"fmt"
 	"github.com/bevgene/go-currency-rate/app/clients"
+	"github.com/bevgene/go-currency-rate/app/model"
 	"github.com/go-masonry/mortar/interfaces/cfg"
 	"github.com/go-masonry/mortar/interfaces/log"
+	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/sdk/client"
 	"go.uber.org/fx"
 )
 
 type (
-	cronStarterDeps struct {
+	scheduleStarterDeps struct {
 		fx.In
 
-		Lifecycle           fx.Lifecycle
-		Config              cfg.Config
-		Logger              log.Logger
-		TemporalClient      *clients.LazyClient
-		UpdateRatesWorkflow *UpdateRatesWorkflow
+		Lifecycle              fx.Lifecycle
+		Config                 cfg.Config
+		Logger                 log.Logger
+		TemporalClient         *clients.LazyClient
+		RatesSchedulerWorkflow *RatesSchedulerWorkflow
 	}
 
-	CronStarter struct {
-		deps       cronStarterDeps
-		WorkflowID string
-		RunID      string
+	// ScheduleStarter makes sure the schedule of every asset class is running. Schedules outlive the service, they're
+	// created only when absent and left running when the service stops.
+	ScheduleStarter struct {
+		deps scheduleStarterDeps
 	}
 )
 
-func CreateCronStarter(deps cronStarterDeps) error {
-	cronStarter := &CronStarter{
+func CreateScheduleStarter(deps scheduleStarterDeps) error {
+	scheduleStarter := &ScheduleStarter{
 		deps: deps,
 	}
 
 	deps.Lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) (err error) {
-			workflowName := deps.Config.Get(workflowNameKey).String()
-			workflowID := fmt.Sprintf("cron_%s", workflowName)
-			queueName := deps.Config.Get(queueNameKey).String()
-			cronSchedule := deps.Config.Get(cronScheduleKey).String()
-			workflowOptions := client.StartWorkflowOptions{
-				ID:           workflowID,
-				TaskQueue:    queueName,
-				CronSchedule: cronSchedule,
+			var assetClasses []clients.AssetClassConfig
+			if assetClasses, err = clients.ReadAssetClasses(deps.Config); err != nil {
+				return
 			}
-			var workflowRun client.WorkflowRun
-			if workflowRun, err = cronStarter.deps.TemporalClient.Client.ExecuteWorkflow(context.Background(), workflowOptions, deps.UpdateRatesWorkflow.UpdateRates); err != nil {
-				deps.Logger.WithError(err).Error(ctx, "failed workflow execution")
+			if err = scheduleStarter.start(ctx, model.AssetClassFiat, deps.Config.Get(cronScheduleKey).String()); err != nil {
 				return
 			}
-			cronStarter.WorkflowID = workflowRun.GetID()
-			cronStarter.RunID = workflowRun.GetRunID()
-			deps.Logger.WithField("workflow id", cronStarter.WorkflowID).WithField("run_id", cronStarter.RunID).Info(ctx, "starter started workflow")
-			return
-		},
-		OnStop: func(ctx context.Context) (err error) {
-			if len(cronStarter.WorkflowID) > 0 && len(cronStarter.RunID) > 0 {
-				err = cronStarter.deps.TemporalClient.Client.TerminateWorkflow(ctx, cronStarter.WorkflowID, cronStarter.RunID, "stopping")
+			for _, assetClass := range assetClasses {
+				if err = scheduleStarter.start(ctx, assetClass.Class, assetClass.CronSchedule); err != nil {
+					return
+				}
 			}
 			return
 		},
 	})
 	return nil
 }
+
+// ScheduleWorkflowID is the id of the schedule of an asset class, the fiat one has no class suffix
+func ScheduleWorkflowID(config cfg.Config, assetClass model.AssetClass) string {
+	workflowName := config.Get(workflowNameKey).String()
+	if assetClass == model.AssetClassFiat {
+		return fmt.Sprintf("schedule_%s", workflowName)
+	}
+	return fmt.Sprintf("schedule_%s_%s", workflowName, assetClass)
+}
+
+// start creates the schedule unless it's already running, the configured spec applies to new schedules only
+func (impl *ScheduleStarter) start(ctx context.Context, assetClass model.AssetClass, cronSchedule string) (err error) {
+	if err = model.ValidateCronSchedule(cronSchedule); err != nil {
+		impl.deps.Logger.WithError(err).WithField("asset class", assetClass).Error(ctx, "invalid schedule")
+		return
+	}
+	impl.stopCronWorkflow(ctx, assetClass)
+	workflowOptions := client.StartWorkflowOptions{
+		ID:        ScheduleWorkflowID(impl.deps.Config, assetClass),
+		TaskQueue: impl.deps.Config.Get(queueNameKey).String(),
+	}
+	state := ScheduleState{
+		AssetClass:   assetClass,
+		CronSchedule: cronSchedule,
+	}
+	// a running schedule, e.g. started by another replica, is returned instead of starting a new one
+	var workflowRun client.WorkflowRun
+	if workflowRun, err = impl.deps.TemporalClient.Client.ExecuteWorkflow(ctx, workflowOptions, impl.deps.RatesSchedulerWorkflow.Schedule, state); err != nil {
+		impl.deps.Logger.WithError(err).WithField("asset class", assetClass).Error(ctx, "failed starting schedule")
+		return
+	}
+	impl.deps.Logger.WithField("workflow id", workflowRun.GetID()).WithField("run_id", workflowRun.GetRunID()).Info(ctx, "schedule is running")
+	return
+}
+
+// stopCronWorkflow terminates the cron workflow previous versions started, it would run alongside the schedule otherwise
+func (impl *ScheduleStarter) stopCronWorkflow(ctx context.Context, assetClass model.AssetClass) {
+	workflowID := fmt.Sprintf("cron_%s", impl.deps.Config.Get(workflowNameKey).String())
+	if assetClass != model.AssetClassFiat {
+		workflowID = fmt.Sprintf("%s_%s", workflowID, assetClass)
+	}
+	err := impl.deps.TemporalClient.Client.TerminateWorkflow(ctx, workflowID, "", "replaced by a schedule")
+	switch err.(type) {
+	case nil:
+		impl.deps.Logger.WithField("workflow id", workflowID).Info(ctx, "terminated cron workflow")
+	case *serviceerror.NotFound:
+	default:
+		impl.deps.Logger.WithError(err).WithField("workflow id", workflowID).Warn(ctx, "failed terminating cron workflow")
+	}
+}
@@ -17,12 +17,14 @@ type (
 	workerDeps struct {
 		fx.In
 
-		LazyTemporalClient  *clients.LazyClient
-		Config              cfg.Config
-		Logger              log.Logger
-		Lifecycle           fx.Lifecycle
-		UpdateRatesWorkflow *UpdateRatesWorkflow
-		ExchangeActivities  *ExchangeActivities
+		LazyTemporalClient     *clients.LazyClient
+		Config                 cfg.Config
+		Logger                 log.Logger
+		Lifecycle              fx.Lifecycle
+		UpdateRatesWorkflow    *UpdateRatesWorkflow
+		BackfillRatesWorkflow  *BackfillRatesWorkflow
+		RatesSchedulerWorkflow *RatesSchedulerWorkflow
+		ExchangeActivities     *ExchangeActivities
 	}
 
 	CronWorker struct {
@@ -47,8 +49,16 @@ func CreateWorker(deps workerDeps) error {
 				MaxConcurrentWorkflowTaskExecutionSize: maxConcurrentWorkers,
 			})
 			worker.RegisterWorkflow(deps.UpdateRatesWorkflow.UpdateRates)
+			worker.RegisterWorkflow(deps.BackfillRatesWorkflow.Backfill)
+			worker.RegisterWorkflow(deps.RatesSchedulerWorkflow.Schedule)
 			worker.RegisterActivity(deps.ExchangeActivities.GetRates)
+			worker.RegisterActivity(deps.ExchangeActivities.ValidateRates)
+			worker.RegisterActivity(deps.ExchangeActivities.ValidateHistoricalRates)
+			worker.RegisterActivity(deps.ExchangeActivities.QuarantineRates)
 			worker.RegisterActivity(deps.ExchangeActivities.UpdateRates)
+			worker.RegisterActivity(deps.ExchangeActivities.GetHistoricalRates)
+			worker.RegisterActivity(deps.ExchangeActivities.FindMissingRates)
+			worker.RegisterActivity(deps.ExchangeActivities.AddHistoricalRates)
 
 			if startErr = worker.Start(); startErr != nil {
 				return
@@ -1,9 +1,14 @@
 package temporal
 
 import (
+	"context"
+	"fmt"
+	"github.com/bevgene/go-currency-rate/app/clients"
 	"github.com/bevgene/go-currency-rate/app/model"
 	"github.com/go-masonry/mortar/interfaces/cfg"
 	"github.com/go-masonry/mortar/interfaces/log"
+	"github.com/shopspring/decimal"
+	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 	"go.uber.org/fx"
 	"time"
@@ -15,12 +20,31 @@ type (
 
 		Config             cfg.Config
 		Logger             log.Logger
+		ExchangeClient     clients.ExchangeClient
 		ExchangeActivities *ExchangeActivities
 	}
 
 	UpdateRatesWorkflow struct {
 		deps updateRatesWorkflowDeps
 	}
+
+	// workflowSettings is read from the configuration once per run and recorded in the workflow history
+	workflowSettings struct {
+		Providers        []string
+		ProviderAttempts int32
+		Consensus        consensusSettings
+		MaxChangePercent float64
+		// CanonicalBase every stored document is rebased to, the provider base is kept when empty
+		CanonicalBase string
+	}
+
+	consensusSettings struct {
+		Enabled bool `mapstructure:"enabled"`
+		// Base every provider is rebased to before comparing rates
+		Base            string  `mapstructure:"base"`
+		MaxDeviationBps float64 `mapstructure:"maxDeviationBps"`
+		MinSources      int     `mapstructure:"minSources"`
+	}
 )
 
 func CreateUpdateRatesWorkflow(deps updateRatesWorkflowDeps) *UpdateRatesWorkflow {
@@ -29,26 +53,210 @@ func CreateUpdateRatesWorkflow(deps updateRatesWorkflowDeps) *UpdateRatesWorkflo
 	}
 }
 
-func (impl *UpdateRatesWorkflow) UpdateRates(ctx workflow.Context) (err error) {
-	workflow.GetLogger(ctx).Info("Cron workflow started.", "StartTime", workflow.Now(ctx))
+// UpdateRates fetches and stores the rates of an asset class, runs started without one update the fiat rates.
+// It returns the creation time of the stored rates.
+func (impl *UpdateRatesWorkflow) UpdateRates(ctx workflow.Context, assetClass model.AssetClass) (createdAt time.Time, err error) {
+	if assetClass, err = model.ParseAssetClass(string(assetClass)); err != nil {
+		workflow.GetLogger(ctx).Error("Cron job failed.", "Error", err)
+		return
+	}
+	workflow.GetLogger(ctx).Info("Cron workflow started.", "StartTime", workflow.Now(ctx), "AssetClass", assetClass)
 	activityOptions := workflow.ActivityOptions{
 		StartToCloseTimeout:    time.Minute,
 		ScheduleToStartTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    10 * time.Second,
+			MaximumAttempts:    storageAttempts,
+		},
 	}
 	ctx1 := workflow.WithActivityOptions(ctx, activityOptions)
-	var rates model.ExchangeRatesModel
-	if err = workflow.ExecuteActivity(ctx1, impl.deps.ExchangeActivities.GetRates).Get(ctx, &rates); err != nil {
+
+	// the configuration might change between runs, it's recorded so that replays use the same settings
+	var settings workflowSettings
+	if err = workflow.SideEffect(ctx, func(workflow.Context) interface{} {
+		return impl.settings(assetClass)
+	}).Get(&settings); err != nil {
 		workflow.GetLogger(ctx).Error("Cron job failed.", "Error", err)
 		return
 	}
+	// every provider gets a few attempts of its own before falling back to the next one
+	providerOptions := activityOptions
+	providerOptions.RetryPolicy = &temporal.RetryPolicy{
+		InitialInterval:    time.Second,
+		BackoffCoefficient: 2,
+		MaximumInterval:    10 * time.Second,
+		MaximumAttempts:    settings.ProviderAttempts,
+	}
+	providerCtx := workflow.WithActivityOptions(ctx, providerOptions)
 
-	document := model.ConvertExchangeRatesModel(rates)
+	var document *model.ExchangeRateDocument
+	if settings.Consensus.Enabled && assetClass == model.AssetClassFiat {
+		document, err = impl.consensusRates(ctx, providerCtx, settings)
+	} else {
+		document, err = impl.failoverRates(ctx, providerCtx, assetClass, settings)
+	}
+	if err != nil {
+		workflow.GetLogger(ctx).Error("Cron job failed.", "Error", err)
+		return
+	}
+	if assetClass == model.AssetClassFiat {
+		// fiat providers quote the other classes as well, they're kept until the class is fetched separately
+		document.AssetClass = assetClass
+	} else {
+		document = document.OfAssetClass(assetClass)
+	}
+
+	if err = impl.validateRates(ctx, ctx1, document, settings); err != nil {
+		workflow.GetLogger(ctx).Error("Cron job failed.", "Error", err)
+		return
+	}
 
 	err = workflow.ExecuteActivity(ctx1, impl.deps.ExchangeActivities.UpdateRates, document).Get(ctx, nil)
 	if err != nil {
 		workflow.GetLogger(ctx).Error("Cron job failed.", "Error", err)
+		return
+	}
+	createdAt = document.CreatedAt
+	workflow.GetLogger(ctx).Info("Cron workflow finished.", "FinishTime", workflow.Now(ctx), "Provider", document.Provider)
+	return
+}
+
+// failoverRates returns the rates of the first provider that succeeds
+func (impl *UpdateRatesWorkflow) failoverRates(ctx, providerCtx workflow.Context, assetClass model.AssetClass, settings workflowSettings) (result *model.ExchangeRateDocument, err error) {
+	if len(settings.Providers) == 0 {
+		err = fmt.Errorf("no providers configured for asset class %s", assetClass)
+		return
+	}
+	for _, name := range settings.Providers {
+		var rates model.ExchangeRatesModel
+		if err = workflow.ExecuteActivity(providerCtx, impl.deps.ExchangeActivities.GetRates, name).Get(ctx, &rates); err != nil {
+			workflow.GetLogger(ctx).Warn("Provider failed, falling back to the next one.", "Provider", name, "Error", err)
+			continue
+		}
+		if result, err = canonicalDocument(rates, settings.CanonicalBase); err != nil {
+			workflow.GetLogger(ctx).Warn("Provider rates can't be rebased, falling back to the next one.", "Provider", name, "Error", err)
+			continue
+		}
+		result.Provider = name
+		return
+	}
+	err = fmt.Errorf("all %d providers failed, last error: %v", len(settings.Providers), err)
+	return
+}
+
+// consensusRates fetches the rates of all the providers in parallel and stores the median of every currency
+func (impl *UpdateRatesWorkflow) consensusRates(ctx, providerCtx workflow.Context, settings workflowSettings) (result *model.ExchangeRateDocument, err error) {
+	futures := make([]workflow.Future, 0, len(settings.Providers))
+	for _, name := range settings.Providers {
+		futures = append(futures, workflow.ExecuteActivity(providerCtx, impl.deps.ExchangeActivities.GetRates, name))
+	}
+	sources := make(map[string]model.ExchangeRatesModel, len(futures))
+	for i, future := range futures {
+		name := settings.Providers[i]
+		var rates model.ExchangeRatesModel
+		if providerErr := future.Get(ctx, &rates); providerErr != nil {
+			workflow.GetLogger(ctx).Warn("Provider failed, it's excluded from the consensus.", "Provider", name, "Error", providerErr)
+			continue
+		}
+		rebased, rebaseErr := rates.Rebase(settings.Consensus.Base)
+		if rebaseErr != nil {
+			workflow.GetLogger(ctx).Warn("Provider rates can't be compared, it's excluded from the consensus.", "Provider", name, "Error", rebaseErr)
+			continue
+		}
+		sources[name] = rebased
+	}
+	if len(sources) < settings.Consensus.MinSources {
+		err = fmt.Errorf("only %d of %d providers succeeded, at least %d are required", len(sources), len(settings.Providers), settings.Consensus.MinSources)
+		return
+	}
+	var consensus model.ConsensusRates
+	if consensus, err = model.Consensus(sources, settings.Consensus.Base, decimal.NewFromFloat(settings.Consensus.MaxDeviationBps)); err != nil {
+		return
+	}
+	for currency, rejected := range consensus.Rejected {
+		workflow.GetLogger(ctx).Warn("Outlier rates rejected.", "Currency", currency, "Providers", rejected)
+	}
+	if result, err = canonicalDocument(consensus.Rates, settings.CanonicalBase); err != nil {
+		return
+	}
+	result.Provider = ConsensusProvider
+	result.Sources = consensus.Sources
+	result.Rejected = consensus.Rejected
+	return
+}
 
+// canonicalDocument rebases the rates to the canonical base and converts them to a document
+func canonicalDocument(rates model.ExchangeRatesModel, canonicalBase string) (result *model.ExchangeRateDocument, err error) {
+	rebased := rates
+	if len(canonicalBase) > 0 {
+		if rebased, err = rates.Rebase(canonicalBase); err != nil {
+			return
+		}
+	}
+	result = model.ConvertExchangeRatesModel(rebased)
+	result.ProviderBase = rates.Base
+	return
+}
+
+// validateRates quarantines the document instead of storing it when it fails the sanity checks
+func (impl *UpdateRatesWorkflow) validateRates(ctx, activityCtx workflow.Context, document *model.ExchangeRateDocument, settings workflowSettings) (err error) {
+	var violations []string
+	if err = workflow.ExecuteActivity(activityCtx, impl.deps.ExchangeActivities.ValidateRates, document, settings.MaxChangePercent).Get(ctx, &violations); err != nil {
+		return
+	}
+	if len(violations) == 0 {
+		return
+	}
+	workflow.GetLogger(ctx).Warn("Rates failed validation, they're quarantined.", "Provider", document.Provider, "Violations", violations)
+	if err = workflow.ExecuteActivity(activityCtx, impl.deps.ExchangeActivities.QuarantineRates, document, violations).Get(ctx, nil); err != nil {
+		return
+	}
+	err = fmt.Errorf("rates of %s failed validation: %v", document.Provider, violations)
+	return
+}
+
+func (impl *UpdateRatesWorkflow) settings(assetClass model.AssetClass) (result workflowSettings) {
+	result = workflowSettings{
+		Providers:        impl.providers(assetClass),
+		ProviderAttempts: impl.deps.Config.Get(providerAttemptsKey).Int32(),
+		MaxChangePercent: impl.deps.Config.Get(maxChangePercentKey).Float64(),
+		CanonicalBase:    impl.deps.Config.Get(canonicalBaseKey).String(),
+	}
+	if result.ProviderAttempts <= 0 {
+		result.ProviderAttempts = defaultProviderAttempts
+	}
+	if err := impl.deps.Config.Get(consensusKey).Unmarshal(&result.Consensus); err != nil {
+		// an invalid consensus configuration falls back to the plain failover
+		impl.deps.Logger.WithError(err).Warn(context.Background(), "invalid consensus configuration, it's disabled")
+		result.Consensus = consensusSettings{}
+	}
+	return
+}
+
+// providers returns the providers of an asset class, the fiat class gets all the providers not assigned to another class
+func (impl *UpdateRatesWorkflow) providers(assetClass model.AssetClass) (result []string) {
+	assetClasses, err := clients.ReadAssetClasses(impl.deps.Config)
+	if err != nil {
+		impl.deps.Logger.WithError(err).Warn(context.Background(), "invalid asset classes configuration")
+	}
+	assigned := make(map[string]bool)
+	for _, classConfig := range assetClasses {
+		if classConfig.Class == assetClass {
+			return classConfig.Providers
+		}
+		for _, name := range classConfig.Providers {
+			assigned[name] = true
+		}
+	}
+	if assetClass != model.AssetClassFiat {
+		return
+	}
+	for _, name := range impl.deps.ExchangeClient.Providers() {
+		if !assigned[name] {
+			result = append(result, name)
+		}
 	}
-	workflow.GetLogger(ctx).Info("Cron workflow finished.", "FinishTime", workflow.Now(ctx))
 	return
 }
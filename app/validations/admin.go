@@ -0,0 +1,205 @@
+package validations
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/go-masonry/mortar/interfaces/cfg"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	currencyconverter "github.com/bevgene/go-currency-rate/api"
+	"github.com/go-masonry/mortar/interfaces/auth/jwt"
+	"github.com/go-masonry/mortar/interfaces/log"
+	"go.uber.org/fx"
+)
+
+const (
+	adminRolesClaimKey = "exchangerate.admin.rolesClaim"
+	adminRoleKey       = "exchangerate.admin.role"
+	adminSecretKey     = "exchangerate.admin.tokenSecret"
+)
+
+type (
+	AdminValidations interface {
+		ValidateRefreshRatesRequest(ctx context.Context, request *currencyconverter.RefreshRatesRequest) error
+		ValidateBackfillRatesRequest(ctx context.Context, request *currencyconverter.BackfillRatesRequest) error
+		ValidateGetScheduleRequest(ctx context.Context, request *currencyconverter.GetScheduleRequest) error
+		ValidateScheduleSignalRequest(ctx context.Context, request *currencyconverter.ScheduleSignalRequest) error
+		ValidateUpdateScheduleRequest(ctx context.Context, request *currencyconverter.UpdateScheduleRequest) error
+	}
+
+	adminValidationsImplDeps struct {
+		fx.In
+
+		Logger log.Logger
+		Config cfg.Config
+		// TokenExtractor is provided by mortar.AuthFxOptions, every admin request is rejected without it
+		TokenExtractor jwt.TokenExtractor `optional:"true"`
+	}
+
+	adminValidationsImpl struct {
+		deps adminValidationsImplDeps
+	}
+)
+
+func CreateAdminValidations(deps adminValidationsImplDeps) AdminValidations {
+	return &adminValidationsImpl{
+		deps: deps,
+	}
+}
+
+func (impl *adminValidationsImpl) ValidateRefreshRatesRequest(ctx context.Context, request *currencyconverter.RefreshRatesRequest) (err error) {
+	if err = impl.authorize(ctx); err != nil {
+		return
+	}
+	return impl.assetClass(ctx, request.GetAssetClass())
+}
+
+func (impl *adminValidationsImpl) ValidateBackfillRatesRequest(ctx context.Context, request *currencyconverter.BackfillRatesRequest) (err error) {
+	if err = impl.authorize(ctx); err != nil {
+		return
+	}
+	switch {
+	case request.GetStart() == nil || request.GetEnd() == nil:
+		err = status.Errorf(codes.InvalidArgument, "start and end are required")
+	case !request.GetStart().AsTime().Before(request.GetEnd().AsTime()):
+		err = status.Errorf(codes.InvalidArgument, "end must be after start")
+	case request.GetStart().AsTime().After(time.Now()):
+		err = status.Errorf(codes.InvalidArgument, "start can't be in the future")
+	}
+	if err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "invalid backfill range")
+	}
+	return
+}
+
+func (impl *adminValidationsImpl) ValidateGetScheduleRequest(ctx context.Context, request *currencyconverter.GetScheduleRequest) (err error) {
+	if err = impl.authorize(ctx); err != nil {
+		return
+	}
+	return impl.assetClass(ctx, request.GetAssetClass())
+}
+
+func (impl *adminValidationsImpl) ValidateScheduleSignalRequest(ctx context.Context, request *currencyconverter.ScheduleSignalRequest) (err error) {
+	if err = impl.authorize(ctx); err != nil {
+		return
+	}
+	return impl.assetClass(ctx, request.GetAssetClass())
+}
+
+func (impl *adminValidationsImpl) ValidateUpdateScheduleRequest(ctx context.Context, request *currencyconverter.UpdateScheduleRequest) (err error) {
+	if err = impl.authorize(ctx); err != nil {
+		return
+	}
+	if err = impl.assetClass(ctx, request.GetAssetClass()); err != nil {
+		return
+	}
+	if err = model.ValidateCronSchedule(request.GetCronSchedule()); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "invalid cron schedule")
+		err = status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return
+}
+
+func (impl *adminValidationsImpl) assetClass(ctx context.Context, value string) (err error) {
+	if _, err = model.ParseAssetClass(value); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "invalid asset class")
+		err = status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return
+}
+
+// authorize makes sure the caller JWT is signed with the configured secret (HS256) and has the admin role in its roles
+// claim, which can be either a string or a list. Admin requests are rejected when no secret is configured.
+func (impl *adminValidationsImpl) authorize(ctx context.Context) (err error) {
+	secret := impl.deps.Config.Get(adminSecretKey).String()
+	if impl.deps.TokenExtractor == nil || len(secret) == 0 {
+		impl.deps.Logger.Error(ctx, "no token extractor or secret, admin requests are disabled")
+		return status.Errorf(codes.Unauthenticated, "authentication isn't configured")
+	}
+	var token jwt.Token
+	if token, err = impl.deps.TokenExtractor.FromContext(ctx); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "failed extracting token")
+		return status.Errorf(codes.Unauthenticated, "missing or invalid token")
+	}
+	if err = verifyToken(token, []byte(secret), time.Now()); err != nil {
+		impl.deps.Logger.WithError(err).Warn(ctx, "token verification failed")
+		return status.Errorf(codes.Unauthenticated, "missing or invalid token")
+	}
+	var claims map[string]interface{}
+	if claims, err = token.Map(); err != nil {
+		impl.deps.Logger.WithError(err).Error(ctx, "failed decoding token")
+		return status.Errorf(codes.Unauthenticated, "missing or invalid token")
+	}
+	rolesClaim := impl.deps.Config.Get(adminRolesClaimKey).String()
+	adminRole := impl.deps.Config.Get(adminRoleKey).String()
+	switch roles := claims[rolesClaim].(type) {
+	case string:
+		if roles == adminRole {
+			return nil
+		}
+	case []interface{}:
+		for _, role := range roles {
+			if role == adminRole {
+				return nil
+			}
+		}
+	}
+	impl.deps.Logger.WithField("roles", claims[rolesClaim]).Warn(ctx, "caller isn't an admin")
+	return status.Errorf(codes.PermissionDenied, "%s role is required", adminRole)
+}
+
+// verifyToken checks the HS256 signature of the token and its expiration, when it has one. Any other algorithm, "none"
+// included, is rejected so that the header can't downgrade the verification.
+func verifyToken(token jwt.Token, secret []byte, now time.Time) (err error) {
+	parts := strings.Split(token.Raw(), ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("token has %d parts", len(parts))
+	}
+	var header struct {
+		Algorithm string `json:"alg"`
+	}
+	var raw []byte
+	if raw, err = base64.RawURLEncoding.DecodeString(parts[0]); err != nil {
+		return fmt.Errorf("failed decoding token header: %w", err)
+	}
+	if err = json.Unmarshal(raw, &header); err != nil {
+		return fmt.Errorf("failed decoding token header: %w", err)
+	}
+	if header.Algorithm != "HS256" {
+		return fmt.Errorf("unsupported token algorithm %q", header.Algorithm)
+	}
+	var signature []byte
+	if signature, err = base64.RawURLEncoding.DecodeString(parts[2]); err != nil {
+		return fmt.Errorf("failed decoding token signature: %w", err)
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return fmt.Errorf("token signature doesn't match")
+	}
+	var claims struct {
+		ExpiresAt *json.Number `json:"exp"`
+	}
+	if err = token.Decode(&claims); err != nil {
+		return fmt.Errorf("failed decoding token claims: %w", err)
+	}
+	if claims.ExpiresAt != nil {
+		var expiresAt int64
+		if expiresAt, err = claims.ExpiresAt.Int64(); err != nil {
+			return fmt.Errorf("invalid token expiration: %w", err)
+		}
+		if !now.Before(time.Unix(expiresAt, 0)) {
+			return fmt.Errorf("token expired at %s", time.Unix(expiresAt, 0).UTC())
+		}
+	}
+	return nil
+}
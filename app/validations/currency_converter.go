@@ -2,64 +2,269 @@ package validations
 
 import (
 	"context"
-	"go.uber.org/multierr"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	"reflect"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
 
 	currencyconverter "github.com/bevgene/go-currency-rate/api"
+	currencyconverterv2 "github.com/bevgene/go-currency-rate/api/v2"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/go-masonry/mortar/interfaces/cfg"
 	"github.com/go-masonry/mortar/interfaces/log"
+	"github.com/shopspring/decimal"
 	"go.uber.org/fx"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type (
 	CurrencyRateValidations interface {
 		ValidateGetCurrencyRateRequest(ctx context.Context, request *currencyconverter.ConvertRequest) error
+		ValidateBatchConvertRequest(ctx context.Context, request *currencyconverter.BatchConvertRequest) error
+		ValidateGetRateRequest(ctx context.Context, request *currencyconverter.GetRateRequest) error
+		ValidateGetRateHistoryRequest(ctx context.Context, request *currencyconverter.GetRateHistoryRequest) error
+		ValidateCreateQuoteRequest(ctx context.Context, request *currencyconverter.CreateQuoteRequest) error
+		ValidateConvertWithQuoteRequest(ctx context.Context, request *currencyconverter.ConvertWithQuoteRequest) error
+		ValidateWatchRatesRequest(ctx context.Context, request *currencyconverter.WatchRatesRequest) error
+		ValidateListCurrenciesRequest(ctx context.Context, request *currencyconverter.ListCurrenciesRequest) error
+		ValidateConvertRequestV2(ctx context.Context, request *currencyconverterv2.ConvertRequest) error
+		ValidateGetRateRequestV2(ctx context.Context, request *currencyconverterv2.GetRateRequest) error
 	}
 
 	currencyRateValidationsImplDeps struct {
 		fx.In
+
 		Logger log.Logger
+		Config cfg.Config
 	}
 
 	currencyRateValidationsImpl struct {
 		deps currencyRateValidationsImplDeps
+		// extraCurrencies are accepted on top of the known ones, e.g. crypto currencies of an asset class provider
+		extraCurrencies map[string]bool
+		maxAmount       decimal.Decimal
+		// maxAmounts overrides maxAmount per currency_from
+		maxAmounts map[string]decimal.Decimal
 	}
+
+	// rule checks a single field of a request, it returns nil when the field is valid
+	rule func() *errdetails.BadRequest_FieldViolation
+)
+
+const (
+	maxAmountKey       = "exchangerate.requests.maxAmount"
+	maxAmountsKey      = "exchangerate.requests.maxAmounts"
+	extraCurrenciesKey = "exchangerate.requests.extraCurrencies"
 )
 
-func CreateCurrencyRateValidations(deps currencyRateValidationsImplDeps) CurrencyRateValidations {
-	return &currencyRateValidationsImpl{
-		deps: deps,
+// currencyCodePattern keeps the extra currencies safe to use as part of a mongo field path
+var currencyCodePattern = regexp.MustCompile(`^[A-Z0-9]+$`)
+
+func CreateCurrencyRateValidations(deps currencyRateValidationsImplDeps) (result CurrencyRateValidations, err error) {
+	impl := &currencyRateValidationsImpl{
+		deps:            deps,
+		extraCurrencies: make(map[string]bool),
+		maxAmounts:      make(map[string]decimal.Decimal),
+	}
+	if impl.maxAmount, err = decimal.NewFromString(deps.Config.Get(maxAmountKey).String()); err != nil {
+		err = fmt.Errorf("invalid %s: %w", maxAmountKey, err)
+		return
+	}
+	for _, code := range deps.Config.Get(extraCurrenciesKey).StringSlice() {
+		if !currencyCodePattern.MatchString(code) {
+			err = fmt.Errorf("invalid %s: %q isn't an upper case currency code", extraCurrenciesKey, code)
+			return
+		}
+		impl.extraCurrencies[code] = true
+	}
+	for code, value := range deps.Config.Get(maxAmountsKey).StringMapString() {
+		var maxAmount decimal.Decimal
+		if maxAmount, err = decimal.NewFromString(value); err != nil {
+			err = fmt.Errorf("invalid %s of %s: %w", maxAmountsKey, code, err)
+			return
+		}
+		// configuration keys are case insensitive, they're read lower cased
+		impl.maxAmounts[strings.ToUpper(code)] = maxAmount
 	}
+	result = impl
+	return
+}
+
+func (impl *currencyRateValidationsImpl) ValidateGetCurrencyRateRequest(ctx context.Context, request *currencyconverter.ConvertRequest) error {
+	return impl.validate(ctx, append(impl.currencyPair(request.GetCurrencyFrom(), request.GetCurrencyTo()),
+		impl.floatAmount("amount_from", request.GetAmountFrom(), request.GetCurrencyFrom()),
+	)...)
 }
 
-func (impl *currencyRateValidationsImpl) ValidateGetCurrencyRateRequest(ctx context.Context, request *currencyconverter.ConvertRequest) (err error) {
-	return combineErrors(
-		impl.notEmpty(ctx, request.GetCurrencyTo(), "currencyTo"),
-		impl.notEmpty(ctx, request.GetCurrencyFrom(), "currencyFrom"),
-		func() error {
-			if request.GetAmountFrom() < 0 {
-				impl.deps.Logger.WithField("amount", request.GetAmountFrom()).Error(ctx, "amount cannot be negative")
-				return status.Errorf(codes.InvalidArgument, "negative amount")
+func (impl *currencyRateValidationsImpl) ValidateBatchConvertRequest(ctx context.Context, request *currencyconverter.BatchConvertRequest) error {
+	return impl.validate(ctx, func() *errdetails.BadRequest_FieldViolation {
+		if len(request.GetItems()) == 0 {
+			return violation("items", "cannot be empty")
+		}
+		return nil
+	})
+}
+
+func (impl *currencyRateValidationsImpl) ValidateGetRateRequest(ctx context.Context, request *currencyconverter.GetRateRequest) error {
+	return impl.validate(ctx, impl.currencyPair(request.GetCurrencyFrom(), request.GetCurrencyTo())...)
+}
+
+func (impl *currencyRateValidationsImpl) ValidateGetRateHistoryRequest(ctx context.Context, request *currencyconverter.GetRateHistoryRequest) error {
+	return impl.validate(ctx, append(impl.currencyPair(request.GetCurrencyFrom(), request.GetCurrencyTo()),
+		func() *errdetails.BadRequest_FieldViolation {
+			if request.GetStart() == nil {
+				return violation("start", "cannot be empty")
+			}
+			return nil
+		},
+		func() *errdetails.BadRequest_FieldViolation {
+			if request.GetEnd() == nil {
+				return violation("end", "cannot be empty")
+			}
+			if request.GetStart() != nil && !request.GetStart().AsTime().Before(request.GetEnd().AsTime()) {
+				return violation("end", "must be after start")
 			}
 			return nil
-		}(),
+		},
+	)...)
+}
+
+func (impl *currencyRateValidationsImpl) ValidateCreateQuoteRequest(ctx context.Context, request *currencyconverter.CreateQuoteRequest) error {
+	return impl.validate(ctx, impl.currencyPair(request.GetCurrencyFrom(), request.GetCurrencyTo())...)
+}
+
+// ValidateConvertWithQuoteRequest limits the amount to the default maxAmount, the currencies and so their own maxAmounts
+// are only known once the quote is loaded
+func (impl *currencyRateValidationsImpl) ValidateConvertWithQuoteRequest(ctx context.Context, request *currencyconverter.ConvertWithQuoteRequest) error {
+	return impl.validate(ctx,
+		required("quote_id", request.GetQuoteId()),
+		impl.floatAmount("amount_from", request.GetAmountFrom(), ""),
 	)
 }
 
-func combineErrors(errs ...error) (err error) {
-	combinedErrors := multierr.Combine(errs...)
-	if actualErrors := multierr.Errors(combinedErrors); len(actualErrors) > 0 {
-		err = actualErrors[0]
+// ValidateWatchRatesRequest accepts an empty base and no quotes, the stored base and all the currencies are used instead
+func (impl *currencyRateValidationsImpl) ValidateWatchRatesRequest(ctx context.Context, request *currencyconverter.WatchRatesRequest) error {
+	rules := make([]rule, 0, len(request.GetQuotes())+1)
+	if len(request.GetBase()) > 0 {
+		rules = append(rules, impl.currency("base", request.GetBase()))
 	}
-	return
+	for i, quote := range request.GetQuotes() {
+		rules = append(rules, impl.currency(fmt.Sprintf("quotes[%d]", i), quote))
+	}
+	return impl.validate(ctx, rules...)
 }
 
-func (impl *currencyRateValidationsImpl) notEmpty(ctx context.Context, value interface{}, fieldName string) (err error) {
-	if value == reflect.Zero(reflect.TypeOf(value)).Interface() {
-		impl.deps.Logger.WithField(fieldName, value).Error(ctx, "cannot be empty")
-		err = status.Errorf(codes.InvalidArgument, "%s cannot be empty", fieldName)
+// ValidateListCurrenciesRequest has nothing to check yet, the request has no fields
+func (impl *currencyRateValidationsImpl) ValidateListCurrenciesRequest(ctx context.Context, request *currencyconverter.ListCurrenciesRequest) error {
+	return impl.validate(ctx)
+}
+
+func (impl *currencyRateValidationsImpl) ValidateConvertRequestV2(ctx context.Context, request *currencyconverterv2.ConvertRequest) error {
+	return impl.validate(ctx, append(impl.currencyPair(request.GetCurrencyFrom(), request.GetCurrencyTo()),
+		func() *errdetails.BadRequest_FieldViolation {
+			amount, err := decimal.NewFromString(request.GetAmountFrom())
+			if err != nil {
+				return violation("amount_from", fmt.Sprintf("%q is not a decimal number", request.GetAmountFrom()))
+			}
+			return impl.amount("amount_from", amount, request.GetCurrencyFrom())
+		},
+	)...)
+}
+
+func (impl *currencyRateValidationsImpl) ValidateGetRateRequestV2(ctx context.Context, request *currencyconverterv2.GetRateRequest) error {
+	return impl.validate(ctx, impl.currencyPair(request.GetCurrencyFrom(), request.GetCurrencyTo())...)
+}
+
+// validate runs all the rules, every violation is reported as a field violation of the INVALID_ARGUMENT error
+func (impl *currencyRateValidationsImpl) validate(ctx context.Context, rules ...rule) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, check := range rules {
+		if fieldViolation := check(); fieldViolation != nil {
+			violations = append(violations, fieldViolation)
+		}
 	}
+	if len(violations) == 0 {
+		return nil
+	}
+	descriptions := make([]string, 0, len(violations))
+	for _, fieldViolation := range violations {
+		descriptions = append(descriptions, fmt.Sprintf("%s %s", fieldViolation.GetField(), fieldViolation.GetDescription()))
+	}
+	impl.deps.Logger.WithField("violations", descriptions).Error(ctx, "invalid request")
+	result := status.New(codes.InvalidArgument, strings.Join(descriptions, ", "))
+	if detailed, err := result.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		result = detailed
+	}
+	return result.Err()
+}
 
-	return
+// currencyPair checks both currencies of a pair, and that they're different
+func (impl *currencyRateValidationsImpl) currencyPair(currencyFrom, currencyTo string) []rule {
+	return []rule{
+		impl.currency("currency_from", currencyFrom),
+		impl.currency("currency_to", currencyTo),
+		func() *errdetails.BadRequest_FieldViolation {
+			if len(currencyFrom) > 0 && currencyFrom == currencyTo {
+				return violation("currency_to", "must be different from currency_from")
+			}
+			return nil
+		},
+	}
+}
+
+// currency accepts the currencies of every asset class, see model.IsSupportedCurrency, along with the extra currencies
+func (impl *currencyRateValidationsImpl) currency(field, code string) rule {
+	return func() *errdetails.BadRequest_FieldViolation {
+		switch {
+		case len(code) == 0:
+			return violation(field, "cannot be empty")
+		case impl.extraCurrencies[code]:
+			return nil
+		case !model.IsSupportedCurrency(code):
+			return violation(field, fmt.Sprintf("%q isn't an upper case currency code of any asset class", code))
+		}
+		return nil
+	}
+}
+
+func (impl *currencyRateValidationsImpl) floatAmount(field string, amount float32, currency string) rule {
+	return func() *errdetails.BadRequest_FieldViolation {
+		if math.IsNaN(float64(amount)) || math.IsInf(float64(amount), 0) {
+			return violation(field, "must be a finite number")
+		}
+		return impl.amount(field, decimal.NewFromFloat32(amount), currency)
+	}
+}
+
+// amount must be non negative and within the maximum amount of the currency, the default one when it has none
+func (impl *currencyRateValidationsImpl) amount(field string, amount decimal.Decimal, currency string) *errdetails.BadRequest_FieldViolation {
+	if amount.IsNegative() {
+		return violation(field, "cannot be negative")
+	}
+	maxAmount, ok := impl.maxAmounts[currency]
+	if !ok {
+		maxAmount = impl.maxAmount
+	}
+	if maxAmount.IsPositive() && amount.GreaterThan(maxAmount) {
+		return violation(field, fmt.Sprintf("cannot exceed %s", maxAmount))
+	}
+	return nil
+}
+
+func required(field, value string) rule {
+	return func() *errdetails.BadRequest_FieldViolation {
+		if len(value) == 0 {
+			return violation(field, "cannot be empty")
+		}
+		return nil
+	}
+}
+
+func violation(field, description string) *errdetails.BadRequest_FieldViolation {
+	return &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Description: description,
+	}
 }
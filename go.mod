@@ -10,6 +10,7 @@ require (
 	github.com/go-masonry/bzerolog v1.0.8
 	github.com/go-masonry/mortar v1.0.10
 	github.com/golang/mock v1.5.0
+	github.com/golang/protobuf v1.5.2
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.4.0
 	github.com/leanovate/gopter v0.2.9
 	github.com/m3db/prometheus_client_golang v0.8.1 // indirect
@@ -18,9 +19,12 @@ require (
 	github.com/m3db/prometheus_procfs v0.8.1 // indirect
 	github.com/opentracing/opentracing-go v1.2.0
 	github.com/pborman/uuid v1.2.1
+	github.com/robfig/cron v1.2.0
+	github.com/shopspring/decimal v1.3.1
 	github.com/stretchr/testify v1.7.0
 	github.com/uber-go/tally v3.3.17+incompatible
 	go.mongodb.org/mongo-driver v1.5.2
+	go.temporal.io/api v1.4.1-0.20210318194442-3f93fcec559f
 	go.temporal.io/sdk v1.6.0
 	go.uber.org/fx v1.13.1
 	go.uber.org/multierr v1.6.0
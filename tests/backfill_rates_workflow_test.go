@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/clients"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/bevgene/go-currency-rate/app/temporal"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.temporal.io/sdk/workflow"
+)
+
+type backfillRatesWorkflowTestSuite struct {
+	workflowTestSuite
+}
+
+const backfillConfig = `
+exchangerate:
+  backfill:
+    requestInterval: "1s"
+    slotsPerRun: 2
+`
+
+var errDuplicateKey = mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000, Message: "duplicate key"}}}
+
+func TestBackfillRatesWorkflow(t *testing.T) {
+	suite.Run(t, new(backfillRatesWorkflowTestSuite))
+}
+
+func (impl *backfillRatesWorkflowTestSuite) SetupTest() {
+	impl.setup(impl.writeConfig(backfillConfig))
+}
+
+func (impl *backfillRatesWorkflowTestSuite) TestDailyGaps() {
+	t := impl.T()
+
+	start := time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC)
+	end := start.Add(3 * 24 * time.Hour)
+	impl.deps.MockExchangeClient.EXPECT().HistoricalProviders().Return([]string{"fixer"})
+	// the cron workflow stored rates on the middle day only
+	impl.deps.MockMongoClient.EXPECT().GetRateDocumentTimes(gomock.Any(), start, end).Return([]time.Time{start.Add(36 * time.Hour)}, nil)
+	// the rates of the first day are published at 16:00, the timestamp of the other day isn't within it
+	published := *impl.deps.Rates
+	published.Timestamp = start.Add(16 * time.Hour).Unix()
+	impl.deps.MockExchangeClient.EXPECT().GetHistoricalRates(gomock.Any(), "fixer", start).Return(&published, nil)
+	impl.deps.MockExchangeClient.EXPECT().GetHistoricalRates(gomock.Any(), "fixer", start.Add(48*time.Hour)).Return(impl.deps.Rates, nil)
+	impl.deps.MockMongoClient.EXPECT().GetRateDocumentAt(gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	var stored []*model.ExchangeRateDocument
+	impl.deps.MockMongoClient.EXPECT().AddRateDocument(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, doc *model.ExchangeRateDocument) error {
+		stored = append(stored, doc)
+		return nil
+	}).Times(2)
+
+	impl.env.ExecuteWorkflow(impl.deps.BackfillWorkflow.Backfill, model.BackfillRequest{Start: start, End: end, Interval: model.IntervalDaily})
+	if assert.True(t, impl.env.IsWorkflowCompleted()) && assert.NoError(t, impl.env.GetWorkflowError()) {
+		var result temporal.BackfillResult
+		if assert.NoError(t, impl.env.GetWorkflowResult(&result)) {
+			assert.Equal(t, 2, result.Filled)
+			assert.Empty(t, result.Skipped)
+		}
+		if assert.Len(t, stored, 2) {
+			assert.True(t, start.Add(16*time.Hour).Equal(stored[0].CreatedAt))
+			assert.True(t, start.Add(72*time.Hour-time.Second).Equal(stored[1].CreatedAt))
+			assert.Equal(t, "fixer", stored[0].Provider)
+			assert.Equal(t, "USD", stored[0].Base)
+			assert.Equal(t, model.AssetClassFiat, stored[0].AssetClass)
+		}
+	}
+}
+
+func (impl *backfillRatesWorkflowTestSuite) TestHourlyGapsContinueAsNew() {
+	t := impl.T()
+
+	start := time.Date(2021, 5, 10, 22, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+	impl.deps.MockExchangeClient.EXPECT().HistoricalProviders().Return([]string{"fixer"})
+	impl.deps.MockMongoClient.EXPECT().GetRateDocumentTimes(gomock.Any(), start, end).Return(nil, nil)
+	// both slots of the run are on the same day, its rates are fetched and stored once
+	impl.deps.MockExchangeClient.EXPECT().GetHistoricalRates(gomock.Any(), "fixer", start.Truncate(24*time.Hour)).Return(impl.deps.Rates, nil).Times(1)
+	// the rates of the day were stored by an interrupted run
+	impl.deps.MockMongoClient.EXPECT().GetRateDocumentAt(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+	impl.deps.MockMongoClient.EXPECT().AddRateDocument(gomock.Any(), gomock.Any()).Return(errDuplicateKey).Times(1)
+
+	impl.env.ExecuteWorkflow(impl.deps.BackfillWorkflow.Backfill, model.BackfillRequest{Start: start, End: end, Interval: model.IntervalHourly})
+	if assert.True(t, impl.env.IsWorkflowCompleted()) {
+		var continueAsNewErr *workflow.ContinueAsNewError
+		assert.True(t, errors.As(impl.env.GetWorkflowError(), &continueAsNewErr), "the last slot should be left to a new run")
+	}
+}
+
+// TestRatesPublishedAfterRange expects the slots before the publication time to keep the previous rates
+func (impl *backfillRatesWorkflowTestSuite) TestRatesPublishedAfterRange() {
+	t := impl.T()
+
+	day := time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC)
+	start := day.Add(9 * time.Hour)
+	end := start.Add(2 * time.Hour)
+	published := *impl.deps.Rates
+	published.Timestamp = day.Add(16 * time.Hour).Unix()
+	impl.deps.MockExchangeClient.EXPECT().HistoricalProviders().Return([]string{"fixer"})
+	impl.deps.MockMongoClient.EXPECT().GetRateDocumentTimes(gomock.Any(), start, end).Return(nil, nil)
+	impl.deps.MockExchangeClient.EXPECT().GetHistoricalRates(gomock.Any(), "fixer", day).Return(&published, nil)
+	impl.deps.MockMongoClient.EXPECT().AddRateDocument(gomock.Any(), gomock.Any()).Times(0)
+
+	impl.env.ExecuteWorkflow(impl.deps.BackfillWorkflow.Backfill, model.BackfillRequest{Start: start, End: end, Interval: model.IntervalHourly})
+	if assert.True(t, impl.env.IsWorkflowCompleted()) && assert.NoError(t, impl.env.GetWorkflowError()) {
+		var result temporal.BackfillResult
+		if assert.NoError(t, impl.env.GetWorkflowResult(&result)) {
+			assert.Zero(t, result.Filled)
+		}
+	}
+}
+
+// TestInvalidDayQuarantined expects rates that moved too far from the stored rates before them to be quarantined, even
+// though newer rates are stored already
+func (impl *backfillRatesWorkflowTestSuite) TestInvalidDayQuarantined() {
+	t := impl.T()
+
+	start := time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	published := *impl.deps.Rates
+	published.Timestamp = start.Add(16 * time.Hour).Unix()
+	previous := &model.ExchangeRateDocument{
+		Base:      "USD",
+		CreatedAt: start.Add(-8 * time.Hour),
+		Rates:     map[string]float32{"USD": 1, "EUR": 0.5},
+	}
+	impl.deps.MockExchangeClient.EXPECT().HistoricalProviders().Return([]string{"fixer"})
+	impl.deps.MockMongoClient.EXPECT().GetRateDocumentTimes(gomock.Any(), start, end).Return(nil, nil)
+	impl.deps.MockExchangeClient.EXPECT().GetHistoricalRates(gomock.Any(), "fixer", start).Return(&published, nil)
+	impl.deps.MockMongoClient.EXPECT().GetRateDocumentAt(gomock.Any(), start.Add(16*time.Hour)).Return(previous, nil)
+	var quarantined *model.QuarantinedRatesDocument
+	impl.deps.MockMongoClient.EXPECT().AddQuarantinedRatesDocument(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, doc *model.QuarantinedRatesDocument) error {
+		quarantined = doc
+		return nil
+	})
+	impl.deps.MockMongoClient.EXPECT().AddRateDocument(gomock.Any(), gomock.Any()).Times(0)
+
+	impl.env.ExecuteWorkflow(impl.deps.BackfillWorkflow.Backfill, model.BackfillRequest{Start: start, End: end, Interval: model.IntervalDaily})
+	if assert.True(t, impl.env.IsWorkflowCompleted()) && assert.NoError(t, impl.env.GetWorkflowError()) {
+		var result temporal.BackfillResult
+		if assert.NoError(t, impl.env.GetWorkflowResult(&result)) {
+			assert.Zero(t, result.Filled)
+			assert.Equal(t, []time.Time{start}, result.Quarantined)
+		}
+		if assert.NotNil(t, quarantined) && assert.NotEmpty(t, quarantined.Violations) {
+			assert.Contains(t, quarantined.Violations[0], "USD/EUR moved by")
+		}
+	}
+}
+
+func (impl *backfillRatesWorkflowTestSuite) TestQuotaExceeded() {
+	t := impl.T()
+
+	start := time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC)
+	end := start.Add(2 * 24 * time.Hour)
+	quotaErr := &clients.ProviderError{Provider: "fixer", Type: clients.ErrQuotaExceeded, Message: "104 usage_limit_reached"}
+	impl.deps.MockExchangeClient.EXPECT().HistoricalProviders().Return([]string{"fixer"})
+	impl.deps.MockMongoClient.EXPECT().GetRateDocumentTimes(gomock.Any(), start, end).Return(nil, nil)
+	// the exhausted provider isn't requested again, the backfill stops
+	impl.deps.MockExchangeClient.EXPECT().GetHistoricalRates(gomock.Any(), "fixer", start).Return(nil, quotaErr).Times(1)
+
+	impl.env.ExecuteWorkflow(impl.deps.BackfillWorkflow.Backfill, model.BackfillRequest{Start: start, End: end, Interval: model.IntervalDaily})
+	if assert.True(t, impl.env.IsWorkflowCompleted()) {
+		assert.Error(t, impl.env.GetWorkflowError())
+	}
+}
@@ -27,8 +27,10 @@ func ConvertRequestGenerator() gopter.Gen {
 		},
 		CurrencyGenerator(),
 		CurrencyGenerator(),
-		gen.Float32().SuchThat(func(f float32) bool { return f > 0 }),
-	)
+		gen.Float32().SuchThat(func(f float32) bool { return f > 0 && f <= 1e12 }),
+	).SuchThat(func(elem *currencyconverter.ConvertRequest) bool {
+		return elem.GetCurrencyFrom() != elem.GetCurrencyTo()
+	})
 }
 
 func CurrencyGenerator() gopter.Gen {
@@ -2,9 +2,13 @@ package tests
 
 import (
 	"context"
+	"fmt"
 	currencyconverter "github.com/bevgene/go-currency-rate/api"
+	currencyconverterv2 "github.com/bevgene/go-currency-rate/api/v2"
 	"github.com/bevgene/go-currency-rate/app/clients"
 	mock_clients "github.com/bevgene/go-currency-rate/app/clients/mock"
+	"github.com/bevgene/go-currency-rate/app/temporal"
+	mock_temporal "github.com/bevgene/go-currency-rate/app/temporal/mock"
 	"github.com/go-masonry/mortar/interfaces/cfg"
 	confkeys "github.com/go-masonry/mortar/interfaces/cfg/keys"
 	"github.com/go-masonry/mortar/interfaces/http/client"
@@ -19,6 +23,7 @@ import (
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 )
 
 type (
@@ -26,6 +31,10 @@ type (
 		currencyconverter.CurrencyConverterClient
 	}
 
+	CurrencyConverterV2Client interface {
+		currencyconverterv2.CurrencyConverterClient
+	}
+
 	currencyConverterClientImplDeps struct {
 		fx.In
 
@@ -37,10 +46,23 @@ type (
 		deps   currencyConverterClientImplDeps
 		client utils.ProtobufHTTPClient
 	}
+
+	currencyConverterV2ClientImpl struct {
+		*currencyConverterClientImpl
+	}
 )
 
 const (
-	convertPath = "/v1/convert"
+	convertPath            = "/v1/convert"
+	batchConvertPath       = "/v1/convert/batch"
+	currenciesPath         = "/v1/currencies"
+	ratePathFormat         = "/v1/rates/%s/%s"
+	rateHistoryPathFormat  = "/v1/rates/%s/%s/history"
+	quotesPath             = "/v1/quotes"
+	quoteConvertPathFormat = "/v1/quotes/%s/convert"
+
+	convertV2Path    = "/v2/convert"
+	rateV2PathFormat = "/v2/rates/%s/%s"
 )
 
 func NewMockController(t *testing.T) (*gomock.Controller, context.Context) {
@@ -55,6 +77,14 @@ func CreateMongoClientMock(mock *mock_clients.MockMongoClient) clients.MongoClie
 	return mock
 }
 
+func CreateRatesRefresherMock(mock *mock_temporal.MockRatesRefresher) temporal.RatesRefresher {
+	return mock
+}
+
+func CreateScheduleManagerMock(mock *mock_temporal.MockScheduleManager) temporal.ScheduleManager {
+	return mock
+}
+
 func CreateLazyMongoClient(mock *mock_clients.MockMongoClient) *clients.LazyMongoClient {
 	lazyClient := new(clients.LazyMongoClient)
 	lazyClient.Client = mock
@@ -69,19 +99,117 @@ func CreateCurrencyConverterClient(deps currencyConverterClientImplDeps) Currenc
 	}
 }
 
+// CreateCurrencyConverterGRPCClient connects directly to the gRPC port, it's needed for streaming APIs
+func CreateCurrencyConverterGRPCClient(config cfg.Config, lc fx.Lifecycle) (currencyconverter.CurrencyConverterClient, error) {
+	serverPort := config.Get(confkeys.ExternalGRPCPort).String()
+	conn, err := grpc.Dial(net.JoinHostPort("localhost", serverPort), grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return conn.Close()
+		},
+	})
+	return currencyconverter.NewCurrencyConverterClient(conn), nil
+}
+
+func CreateCurrencyConverterAdminGRPCClient(config cfg.Config, lc fx.Lifecycle) (currencyconverter.CurrencyConverterAdminClient, error) {
+	serverPort := config.Get(confkeys.ExternalGRPCPort).String()
+	conn, err := grpc.Dial(net.JoinHostPort("localhost", serverPort), grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return conn.Close()
+		},
+	})
+	return currencyconverter.NewCurrencyConverterAdminClient(conn), nil
+}
+
+func CreateCurrencyConverterV2Client(deps currencyConverterClientImplDeps) CurrencyConverterV2Client {
+	httpClient := deps.HTTPClientBuilder().Build()
+	return &currencyConverterV2ClientImpl{
+		currencyConverterClientImpl: &currencyConverterClientImpl{
+			deps:   deps,
+			client: utils.CreateProtobufHTTPClient(httpClient, convertHTTPStatusCodeToGRPCError, nil),
+		},
+	}
+}
+
 func (impl *currencyConverterClientImpl) Convert(ctx context.Context, request *currencyconverter.ConvertRequest, opts ...grpc.CallOption) (result *currencyconverter.ConvertResponse, err error) {
-	err = impl.callCurrencyConverter(ctx, convertPath, request, &result)
+	err = impl.callCurrencyConverter(ctx, http.MethodPost, convertPath, request, &result)
+	return
+}
+
+func (impl *currencyConverterClientImpl) BatchConvert(ctx context.Context, request *currencyconverter.BatchConvertRequest, opts ...grpc.CallOption) (result *currencyconverter.BatchConvertResponse, err error) {
+	err = impl.callCurrencyConverter(ctx, http.MethodPost, batchConvertPath, request, &result)
 	return
 }
 
-func (impl *currencyConverterClientImpl) callCurrencyConverter(ctx context.Context, path string, request proto.Message, response interface{}) (err error) {
+// WatchRates is a server stream, use a gRPC connection to test it
+func (impl *currencyConverterClientImpl) WatchRates(ctx context.Context, request *currencyconverter.WatchRatesRequest, opts ...grpc.CallOption) (currencyconverter.CurrencyConverter_WatchRatesClient, error) {
+	return nil, status.Error(codes.Unimplemented, "streaming is not supported by the REST test client")
+}
+
+func (impl *currencyConverterClientImpl) ListCurrencies(ctx context.Context, request *currencyconverter.ListCurrenciesRequest, opts ...grpc.CallOption) (result *currencyconverter.ListCurrenciesResponse, err error) {
+	err = impl.callCurrencyConverter(ctx, http.MethodGet, currenciesPath, request, &result)
+	return
+}
+
+func (impl *currencyConverterClientImpl) GetRate(ctx context.Context, request *currencyconverter.GetRateRequest, opts ...grpc.CallOption) (result *currencyconverter.GetRateResponse, err error) {
+	path := fmt.Sprintf(ratePathFormat, request.GetCurrencyFrom(), request.GetCurrencyTo())
+	err = impl.callCurrencyConverter(ctx, http.MethodGet, path, request, &result)
+	return
+}
+
+func (impl *currencyConverterClientImpl) GetRateHistory(ctx context.Context, request *currencyconverter.GetRateHistoryRequest, opts ...grpc.CallOption) (result *currencyconverter.GetRateHistoryResponse, err error) {
+	path := fmt.Sprintf(rateHistoryPathFormat, request.GetCurrencyFrom(), request.GetCurrencyTo())
+	query := url.Values{}
+	query.Set("start", request.GetStart().AsTime().Format(time.RFC3339Nano))
+	query.Set("end", request.GetEnd().AsTime().Format(time.RFC3339Nano))
+	query.Set("interval", request.GetInterval().String())
+	err = impl.callCurrencyConverterWithQuery(ctx, http.MethodGet, path, query, request, &result)
+	return
+}
+
+func (impl *currencyConverterClientImpl) CreateQuote(ctx context.Context, request *currencyconverter.CreateQuoteRequest, opts ...grpc.CallOption) (result *currencyconverter.Quote, err error) {
+	err = impl.callCurrencyConverter(ctx, http.MethodPost, quotesPath, request, &result)
+	return
+}
+
+func (impl *currencyConverterClientImpl) ConvertWithQuote(ctx context.Context, request *currencyconverter.ConvertWithQuoteRequest, opts ...grpc.CallOption) (result *currencyconverter.ConvertResponse, err error) {
+	path := fmt.Sprintf(quoteConvertPathFormat, request.GetQuoteId())
+	err = impl.callCurrencyConverter(ctx, http.MethodPost, path, request, &result)
+	return
+}
+
+func (impl *currencyConverterClientImpl) callCurrencyConverter(ctx context.Context, method, path string, request proto.Message, response interface{}) (err error) {
+	return impl.callCurrencyConverterWithQuery(ctx, method, path, nil, request, response)
+}
+
+// callCurrencyConverterWithQuery is needed for GET APIs with fields that aren't part of the path, the body is ignored by the gateway
+func (impl *currencyConverterClientImpl) callCurrencyConverterWithQuery(ctx context.Context, method, path string, query url.Values, request proto.Message, response interface{}) (err error) {
 	serverPort := impl.deps.Config.Get(confkeys.ExternalRESTPort).String()
 	endpointURL := url.URL{
-		Scheme: "http",
-		Host:   net.JoinHostPort("localhost", serverPort),
-		Path:   path,
+		Scheme:   "http",
+		Host:     net.JoinHostPort("localhost", serverPort),
+		Path:     path,
+		RawQuery: query.Encode(),
 	}
-	return impl.client.Do(ctx, http.MethodPost, endpointURL.String(), request, response)
+	return impl.client.Do(ctx, method, endpointURL.String(), request, response)
+}
+
+func (impl *currencyConverterV2ClientImpl) Convert(ctx context.Context, request *currencyconverterv2.ConvertRequest, opts ...grpc.CallOption) (result *currencyconverterv2.ConvertResponse, err error) {
+	err = impl.callCurrencyConverter(ctx, http.MethodPost, convertV2Path, request, &result)
+	return
+}
+
+func (impl *currencyConverterV2ClientImpl) GetRate(ctx context.Context, request *currencyconverterv2.GetRateRequest, opts ...grpc.CallOption) (result *currencyconverterv2.GetRateResponse, err error) {
+	path := fmt.Sprintf(rateV2PathFormat, request.GetCurrencyFrom(), request.GetCurrencyTo())
+	err = impl.callCurrencyConverter(ctx, http.MethodGet, path, request, &result)
+	return
 }
 
 func convertHTTPStatusCodeToGRPCError(httpStatus int) (result *status.Status) {
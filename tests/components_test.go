@@ -2,21 +2,44 @@ package tests
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	currencyconverter "github.com/bevgene/go-currency-rate/api"
+	currencyconverterv2 "github.com/bevgene/go-currency-rate/api/v2"
 	mock_clients "github.com/bevgene/go-currency-rate/app/clients/mock"
+	"github.com/bevgene/go-currency-rate/app/data"
 	"github.com/bevgene/go-currency-rate/app/model"
 	"github.com/bevgene/go-currency-rate/app/mortar"
+	"github.com/bevgene/go-currency-rate/app/temporal"
+	mock_temporal "github.com/bevgene/go-currency-rate/app/temporal/mock"
+	"github.com/go-masonry/mortar/interfaces/cfg"
+	confkeys "github.com/go-masonry/mortar/interfaces/cfg/keys"
 	"github.com/go-masonry/mortar/interfaces/log"
 	"github.com/go-masonry/mortar/providers"
 	"github.com/golang/mock/gomock"
 	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
 	"github.com/leanovate/gopter/prop"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"go.temporal.io/api/serviceerror"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxtest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 type (
@@ -24,8 +47,15 @@ type (
 		fx.In
 
 		ServiceClient   CurrencyConverterClient
+		ServiceV2Client CurrencyConverterV2Client
+		GRPCClient      currencyconverter.CurrencyConverterClient
+		AdminClient     currencyconverter.CurrencyConverterAdminClient
+		Broadcaster     data.RatesBroadcaster
+		Config          cfg.Config
 		MockCtrl        *gomock.Controller
 		MockMongoClient *mock_clients.MockMongoClient
+		MockRefresher   *mock_temporal.MockRatesRefresher
+		MockSchedules   *mock_temporal.MockScheduleManager
 		Ctx             context.Context
 		Logger          log.Logger
 		ExpectedRates   *model.ExchangeRateDocument
@@ -51,16 +81,24 @@ func (impl *componentTestSuite) SetupTest() {
 		mortar.LoggerFxOption(),
 		mortar.HttpServerFxOptions(),
 		mortar.HttpClientFxOptions(),
+		mortar.AuthFxOptions(),
 		mortar.InternalHttpHandlersFxOptions(),
 		mortar.ServiceAPIsAndOtherDependenciesFxOption(),
 		fx.Provide(
 			NewMockController,
 			CreateCurrencyConverterClient,
+			CreateCurrencyConverterV2Client,
+			CreateCurrencyConverterGRPCClient,
+			CreateCurrencyConverterAdminGRPCClient,
 			mock_clients.NewMockMongoClient,
 			mock_clients.NewMockExchangeClient,
 			CreateExchangeClientMock,
 			CreateMongoClientMock,
 			CreateLazyMongoClient,
+			mock_temporal.NewMockRatesRefresher,
+			CreateRatesRefresherMock,
+			mock_temporal.NewMockScheduleManager,
+			CreateScheduleManagerMock,
 			GetRatesDocument,
 		),
 		providers.BuildMortarWebServiceFxOption(),
@@ -89,6 +127,749 @@ func (impl *componentTestSuite) TestConvert() {
 	props.TestingRun(t)
 }
 
+func (impl *componentTestSuite) TestConvertAsOf() {
+	t := impl.T()
+
+	params := gopter.DefaultTestParametersWithSeed(gopterSeed)
+	props := gopter.NewProperties(params)
+	props.Property("historical convert test", impl.happyConvertAsOf(t))
+	props.TestingRun(t)
+}
+
+func (impl *componentTestSuite) happyConvertAsOf(t *testing.T) gopter.Prop {
+	return prop.ForAll(
+		func(request *currencyconverter.ConvertRequest, asOf time.Time) bool {
+			request.AsOf = timestamppb.New(asOf)
+			impl.deps.MockMongoClient.EXPECT().GetRateDocumentAt(gomock.Any(), asOf.UTC()).Return(impl.deps.ExpectedRates, nil)
+			response, err := impl.deps.ServiceClient.Convert(impl.deps.Ctx, request)
+			if !assert.NoError(t, err, "failed to retrieve convert response") {
+				return false
+			}
+			expectedAmount, err := impl.calculateExpectedAmount(request.GetCurrencyFrom(), request.GetCurrencyTo(),
+				request.GetAmountFrom())
+			if !assert.NoError(t, err, "failed to calculate expected amount") {
+				return false
+			}
+			return assert.NotNil(t, response, "response should not be empty") &&
+				assert.Equal(t, expectedAmount, response.GetAmount()) &&
+				assert.True(t, impl.deps.ExpectedRates.CreatedAt.Equal(response.GetCorrectnessTime().AsTime()))
+		},
+		ConvertRequestGenerator(),
+		gen.TimeRange(time.Now().UTC().Add(-365*24*time.Hour), 365*24*time.Hour),
+	)
+}
+
+func (impl *componentTestSuite) TestBatchConvert() {
+	t := impl.T()
+
+	params := gopter.DefaultTestParametersWithSeed(gopterSeed)
+	props := gopter.NewProperties(params)
+	props.Property("happy batch convert test", impl.happyBatchConvert(t))
+	props.TestingRun(t)
+}
+
+func (impl *componentTestSuite) TestBatchConvertInvalidItem() {
+	t := impl.T()
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+	response, err := impl.deps.ServiceClient.BatchConvert(impl.deps.Ctx, &currencyconverter.BatchConvertRequest{
+		Items: []*currencyconverter.ConvertRequest{
+			{CurrencyFrom: "EUR", CurrencyTo: "USD", AmountFrom: 10},
+			{CurrencyFrom: "EUR", CurrencyTo: "USD", AmountFrom: -10},
+			{CurrencyFrom: "EUR", CurrencyTo: "XXX", AmountFrom: 10},
+		},
+	})
+	if !assert.NoError(t, err, "failed to retrieve batch convert response") {
+		return
+	}
+	results := response.GetResults()
+	if assert.Len(t, results, 3) {
+		assert.NotNil(t, results[0].GetResponse())
+		assert.EqualValues(t, codes.InvalidArgument, results[1].GetError().GetCode())
+		assert.NotNil(t, results[2].GetError())
+	}
+}
+
+// TestBatchConvertAllItemsInvalid expects the per-item errors without reading the rates, even while mongo is down
+func (impl *componentTestSuite) TestBatchConvertAllItemsInvalid() {
+	t := impl.T()
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Times(0)
+	response, err := impl.deps.ServiceClient.BatchConvert(impl.deps.Ctx, &currencyconverter.BatchConvertRequest{
+		Items: []*currencyconverter.ConvertRequest{
+			{CurrencyFrom: "EUR", CurrencyTo: "USD", AmountFrom: -10},
+			{CurrencyFrom: "EUR", CurrencyTo: "XXX", AmountFrom: 10},
+		},
+	})
+	if !assert.NoError(t, err, "failed to retrieve batch convert response") {
+		return
+	}
+	assert.Nil(t, response.GetCorrectnessTime())
+	results := response.GetResults()
+	if assert.Len(t, results, 2) {
+		assert.EqualValues(t, codes.InvalidArgument, results[0].GetError().GetCode())
+		assert.NotNil(t, results[1].GetError())
+	}
+}
+
+func (impl *componentTestSuite) happyBatchConvert(t *testing.T) gopter.Prop {
+	return prop.ForAll(
+		func(items []*currencyconverter.ConvertRequest) bool {
+			impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+			response, err := impl.deps.ServiceClient.BatchConvert(impl.deps.Ctx, &currencyconverter.BatchConvertRequest{Items: items})
+			if !assert.NoError(t, err, "failed to retrieve batch convert response") ||
+				!assert.Len(t, response.GetResults(), len(items)) {
+				return false
+			}
+			for i, item := range items {
+				expectedAmount, err := impl.calculateExpectedAmount(item.GetCurrencyFrom(), item.GetCurrencyTo(), item.GetAmountFrom())
+				if !assert.NoError(t, err, "failed to calculate expected amount") {
+					return false
+				}
+				itemResponse := response.GetResults()[i].GetResponse()
+				if !(assert.NotNil(t, itemResponse, "item response should not be empty") &&
+					assert.EqualValues(t, item.GetCurrencyTo(), itemResponse.GetCurrency()) &&
+					assert.Equal(t, expectedAmount, itemResponse.GetAmount()) &&
+					assert.True(t, response.GetCorrectnessTime().AsTime().Equal(itemResponse.GetCorrectnessTime().AsTime()))) {
+					return false
+				}
+			}
+			return true
+		},
+		gen.SliceOf(ConvertRequestGenerator()).SuchThat(func(items []*currencyconverter.ConvertRequest) bool { return len(items) > 0 }),
+	)
+}
+
+func (impl *componentTestSuite) TestWatchRates() {
+	t := impl.T()
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+	ctx, cancel := context.WithTimeout(impl.deps.Ctx, 5*time.Second)
+	defer cancel()
+	stream, err := impl.deps.GRPCClient.WatchRates(ctx, &currencyconverter.WatchRatesRequest{
+		Base:        "USD",
+		Quotes:      []string{"EUR", "ILS"},
+		ChangesOnly: true,
+	})
+	if !assert.NoError(t, err, "failed to start watching rates") {
+		return
+	}
+	snapshot, err := stream.Recv()
+	if !assert.NoError(t, err, "failed to receive initial snapshot") {
+		return
+	}
+	usdRate := impl.deps.ExpectedRates.Rates["USD"]
+	assert.Equal(t, "USD", snapshot.GetBase())
+	assert.Equal(t, map[string]float32{
+		"EUR": impl.deps.ExpectedRates.Rates["EUR"] / usdRate,
+		"ILS": impl.deps.ExpectedRates.Rates["ILS"] / usdRate,
+	}, snapshot.GetRates())
+
+	updated := &model.ExchangeRateDocument{
+		Base:      impl.deps.ExpectedRates.Base,
+		Rates:     make(map[string]float32, len(impl.deps.ExpectedRates.Rates)),
+		CreatedAt: impl.deps.ExpectedRates.CreatedAt.Add(time.Hour),
+	}
+	for currency, rate := range impl.deps.ExpectedRates.Rates {
+		updated.Rates[currency] = rate
+	}
+	updated.Rates["ILS"] *= 2
+	impl.deps.Broadcaster.Publish(ctx, updated)
+	snapshot, err = stream.Recv()
+	if !assert.NoError(t, err, "failed to receive updated snapshot") {
+		return
+	}
+	assert.Equal(t, map[string]float32{"ILS": updated.Rates["ILS"] / usdRate}, snapshot.GetRates())
+	assert.True(t, updated.CreatedAt.Equal(snapshot.GetCorrectnessTime().AsTime()))
+}
+
+func (impl *componentTestSuite) TestListCurrencies() {
+	t := impl.T()
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+	response, err := impl.deps.ServiceClient.ListCurrencies(impl.deps.Ctx, &currencyconverter.ListCurrenciesRequest{})
+	if !assert.NoError(t, err, "failed to list currencies") ||
+		!assert.Len(t, response.GetCurrencies(), len(impl.deps.ExpectedRates.Rates)) {
+		return
+	}
+	byCode := make(map[string]*currencyconverter.Currency)
+	for _, currency := range response.GetCurrencies() {
+		byCode[currency.GetCode()] = currency
+	}
+	if jpy := byCode["JPY"]; assert.NotNil(t, jpy) {
+		assert.Equal(t, "Yen", jpy.GetName())
+		assert.Equal(t, "392", jpy.GetNumericCode())
+		assert.EqualValues(t, 0, jpy.GetMinorUnits())
+		assert.NotNil(t, jpy.MinorUnits)
+	}
+	if kwd := byCode["KWD"]; assert.NotNil(t, kwd) {
+		assert.EqualValues(t, 3, kwd.GetMinorUnits())
+	}
+	if xau := byCode["XAU"]; assert.NotNil(t, xau) {
+		assert.Nil(t, xau.MinorUnits)
+	}
+}
+
+func (impl *componentTestSuite) TestWatchRatesValidation() {
+	t := impl.T()
+
+	stream, err := impl.deps.GRPCClient.WatchRates(impl.deps.Ctx, &currencyconverter.WatchRatesRequest{
+		Base:   "usd",
+		Quotes: []string{"EUR", "ABC"},
+	})
+	if assert.NoError(t, err) {
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, []string{"base", "quotes[1]"}, violatedFields(err))
+	}
+}
+
+func (impl *componentTestSuite) TestGetRate() {
+	t := impl.T()
+
+	params := gopter.DefaultTestParametersWithSeed(gopterSeed)
+	props := gopter.NewProperties(params)
+	props.Property("happy get rate test", impl.happyGetRate(t))
+	props.TestingRun(t)
+}
+
+func (impl *componentTestSuite) happyGetRate(t *testing.T) gopter.Prop {
+	return prop.ForAll(
+		func(currencyFrom, currencyTo string) bool {
+			if currencyFrom == currencyTo {
+				_, err := impl.deps.GRPCClient.GetRate(impl.deps.Ctx, &currencyconverter.GetRateRequest{
+					CurrencyFrom: currencyFrom,
+					CurrencyTo:   currencyTo,
+				})
+				return assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			}
+			impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+			response, err := impl.deps.ServiceClient.GetRate(impl.deps.Ctx, &currencyconverter.GetRateRequest{
+				CurrencyFrom: currencyFrom,
+				CurrencyTo:   currencyTo,
+			})
+			if !assert.NoError(t, err, "failed to retrieve rate") {
+				return false
+			}
+			rateFrom, rateTo := impl.deps.ExpectedRates.Rates[currencyFrom], impl.deps.ExpectedRates.Rates[currencyTo]
+			return assert.Equal(t, currencyFrom, response.GetCurrencyFrom()) &&
+				assert.Equal(t, currencyTo, response.GetCurrencyTo()) &&
+				assert.Equal(t, rateTo/rateFrom, response.GetRate()) &&
+				assert.Equal(t, rateFrom/rateTo, response.GetInverseRate()) &&
+				assert.Equal(t, impl.deps.ExpectedRates.Base, response.GetBase())
+		},
+		CurrencyGenerator(),
+		CurrencyGenerator(),
+	)
+}
+
+func (impl *componentTestSuite) TestConvertV2Exact() {
+	t := impl.T()
+
+	document := &model.ExchangeRateDocument{
+		Base:  "EUR",
+		Rates: map[string]float32{"EUR": 1, "JPY": 132.6585, "USD": 1.21009},
+		DecimalRates: map[string]model.Decimal{
+			"EUR": model.NewDecimal(decimal.RequireFromString("1")),
+			"JPY": model.NewDecimal(decimal.RequireFromString("132.6585")),
+			"USD": model.NewDecimal(decimal.RequireFromString("1.21009")),
+		},
+		CreatedAt: time.Date(2021, 5, 13, 7, 31, 3, 0, time.UTC),
+	}
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(document, nil).Times(2)
+
+	response, err := impl.deps.ServiceV2Client.Convert(impl.deps.Ctx, &currencyconverterv2.ConvertRequest{
+		CurrencyFrom: "JPY",
+		CurrencyTo:   "USD",
+		AmountFrom:   "1000000",
+	})
+	if assert.NoError(t, err, "failed to retrieve convert response") {
+		assert.Equal(t, "USD", response.GetCurrency())
+		assert.Equal(t, "9121.842927516894884233", response.GetAmount())
+	}
+	rate, err := impl.deps.ServiceV2Client.GetRate(impl.deps.Ctx, &currencyconverterv2.GetRateRequest{
+		CurrencyFrom: "JPY",
+		CurrencyTo:   "USD",
+	})
+	if assert.NoError(t, err, "failed to retrieve rate") {
+		assert.Equal(t, "0.009121842927516895", rate.GetRate())
+		assert.Equal(t, "109.626969894801213133", rate.GetInverseRate())
+	}
+}
+
+func (impl *componentTestSuite) TestConvertV2Rounding() {
+	t := impl.T()
+
+	document := &model.ExchangeRateDocument{
+		Base:  "EUR",
+		Rates: map[string]float32{"EUR": 1, "CHF": 1, "JPY": 132.6585, "KWD": 0.364235, "USD": 1.21009, "BTC": 0.0000237046, "ETH": 0.000312345678, "XAU": 0.000665},
+		DecimalRates: map[string]model.Decimal{
+			"EUR": model.NewDecimal(decimal.RequireFromString("1")),
+			"CHF": model.NewDecimal(decimal.RequireFromString("1")),
+			"JPY": model.NewDecimal(decimal.RequireFromString("132.6585")),
+			"KWD": model.NewDecimal(decimal.RequireFromString("0.364235")),
+			"USD": model.NewDecimal(decimal.RequireFromString("1.21009")),
+			"BTC": model.NewDecimal(decimal.RequireFromString("0.0000237046")),
+			"ETH": model.NewDecimal(decimal.RequireFromString("0.000312345678")),
+			"XAU": model.NewDecimal(decimal.RequireFromString("0.000665")),
+		},
+	}
+	testCases := []struct {
+		currencyTo string
+		amount     string
+		mode       currencyconverterv2.RoundingMode
+		expected   string
+	}{
+		{"JPY", "10", currencyconverterv2.RoundingMode_ROUNDING_MODE_UNSPECIFIED, "1326.585"},
+		{"JPY", "10", currencyconverterv2.RoundingMode_ROUNDING_MODE_HALF_EVEN, "1327"},
+		{"JPY", "10", currencyconverterv2.RoundingMode_ROUNDING_MODE_DOWN, "1326"},
+		{"KWD", "10", currencyconverterv2.RoundingMode_ROUNDING_MODE_HALF_UP, "3.642"},
+		{"KWD", "10", currencyconverterv2.RoundingMode_ROUNDING_MODE_UP, "3.643"},
+		{"USD", "10", currencyconverterv2.RoundingMode_ROUNDING_MODE_HALF_UP, "12.1"},
+		{"USD", "10", currencyconverterv2.RoundingMode_ROUNDING_MODE_UP, "12.11"},
+		{"CHF", "0.125", currencyconverterv2.RoundingMode_ROUNDING_MODE_HALF_EVEN, "0.12"},
+		{"CHF", "0.125", currencyconverterv2.RoundingMode_ROUNDING_MODE_HALF_UP, "0.13"},
+		{"CHF", "0.125", currencyconverterv2.RoundingMode_ROUNDING_MODE_NONE, "0.125"},
+		// crypto currencies and precious metals are rounded to the precision of their asset class
+		{"BTC", "1234.56789", currencyconverterv2.RoundingMode_ROUNDING_MODE_HALF_UP, "0.02926494"},
+		{"BTC", "1234.56789", currencyconverterv2.RoundingMode_ROUNDING_MODE_NONE, "0.029264938005294"},
+		{"ETH", "1", currencyconverterv2.RoundingMode_ROUNDING_MODE_HALF_UP, "0.00031235"},
+		{"XAU", "12.3456789", currencyconverterv2.RoundingMode_ROUNDING_MODE_DOWN, "0.008209"},
+	}
+	for _, testCase := range testCases {
+		impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(document, nil)
+		response, err := impl.deps.ServiceV2Client.Convert(impl.deps.Ctx, &currencyconverterv2.ConvertRequest{
+			CurrencyFrom: "EUR",
+			CurrencyTo:   testCase.currencyTo,
+			AmountFrom:   testCase.amount,
+			RoundingMode: testCase.mode,
+		})
+		if assert.NoError(t, err, "failed to retrieve convert response") {
+			assert.Equal(t, testCase.expected, response.GetAmount(), "%s %s %s", testCase.amount, testCase.currencyTo, testCase.mode)
+		}
+	}
+}
+
+func (impl *componentTestSuite) TestConvertRounding() {
+	t := impl.T()
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+	response, err := impl.deps.ServiceClient.Convert(impl.deps.Ctx, &currencyconverter.ConvertRequest{
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "JPY",
+		AmountFrom:   10,
+		RoundingMode: currencyconverter.RoundingMode_ROUNDING_MODE_HALF_UP,
+	})
+	if assert.NoError(t, err, "failed to retrieve convert response") {
+		expected, _ := decimal.NewFromFloat32(10 * impl.deps.ExpectedRates.Rates["JPY"] / impl.deps.ExpectedRates.Rates["EUR"]).Round(0).Float64()
+		assert.Equal(t, float32(expected), response.GetAmount())
+	}
+}
+
+func (impl *componentTestSuite) TestConvertV2InvalidAmount() {
+	t := impl.T()
+
+	_, err := impl.deps.ServiceV2Client.Convert(impl.deps.Ctx, &currencyconverterv2.ConvertRequest{
+		CurrencyFrom: "JPY",
+		CurrencyTo:   "USD",
+		AmountFrom:   "1,000",
+	})
+	assert.Error(t, err)
+}
+
+// TestConvertValidation expects invalid requests to be rejected before the rates are loaded, with all their violations
+func (impl *componentTestSuite) TestConvertValidation() {
+	t := impl.T()
+
+	_, err := impl.deps.GRPCClient.Convert(impl.deps.Ctx, &currencyconverter.ConvertRequest{
+		CurrencyFrom: "eur",
+		AmountFrom:   float32(math.NaN()),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ElementsMatch(t, []string{"currency_from", "currency_to", "amount_from"}, violatedFields(err))
+
+	_, err = impl.deps.GRPCClient.Convert(impl.deps.Ctx, &currencyconverter.ConvertRequest{
+		CurrencyFrom: "ABC",
+		CurrencyTo:   "USD",
+		AmountFrom:   10,
+	})
+	assert.Equal(t, []string{"currency_from"}, violatedFields(err))
+
+	// crypto tickers longer than three letters are accepted
+	_, err = impl.deps.GRPCClient.Convert(impl.deps.Ctx, &currencyconverter.ConvertRequest{
+		CurrencyFrom: "USDT",
+		CurrencyTo:   "usd",
+		AmountFrom:   10,
+	})
+	assert.Equal(t, []string{"currency_to"}, violatedFields(err))
+
+	statusCode, body := impl.postREST("/v2/convert", `{"currency_from": "USD", "currency_to": "USD", "amount_from": "1000000000000.01"}`)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	assert.Contains(t, body, `"field":"currency_to"`)
+	assert.Contains(t, body, `"field":"amount_from"`)
+}
+
+func violatedFields(err error) (fields []string) {
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				fields = append(fields, violation.GetField())
+			}
+		}
+	}
+	return
+}
+
+func (impl *componentTestSuite) TestConvertOutdatedRates() {
+	t := impl.T()
+
+	request := &currencyconverter.ConvertRequest{
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "USD",
+		AmountFrom:   10,
+	}
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+	response, err := impl.deps.GRPCClient.Convert(impl.deps.Ctx, request)
+	if assert.NoError(t, err, "outdated rates should only be flagged") {
+		assert.Contains(t, response.GetWarning(), "older than the maximum rate age")
+	}
+
+	request.Strict = true
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+	_, err = impl.deps.GRPCClient.Convert(impl.deps.Ctx, request)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+	_, err = impl.deps.ServiceV2Client.Convert(impl.deps.Ctx, &currencyconverterv2.ConvertRequest{
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "USD",
+		AmountFrom:   "10",
+		Strict:       true,
+	})
+	assert.Error(t, err)
+
+	fresh := *impl.deps.ExpectedRates
+	fresh.CreatedAt = time.Now().UTC().Add(-time.Hour)
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(&fresh, nil)
+	response, err = impl.deps.GRPCClient.Convert(impl.deps.Ctx, request)
+	if assert.NoError(t, err, "failed to retrieve convert response") {
+		assert.Empty(t, response.GetWarning())
+	}
+}
+
+func (impl *componentTestSuite) TestUnsupportedCurrency() {
+	t := impl.T()
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+	_, err := impl.deps.GRPCClient.Convert(impl.deps.Ctx, &currencyconverter.ConvertRequest{
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "SSP",
+		AmountFrom:   10,
+	})
+	st := status.Convert(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	var info *errdetails.ErrorInfo
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			info = detail
+		case *errdetails.BadRequest:
+			badRequest = detail
+		}
+	}
+	if assert.NotNil(t, info) {
+		assert.Equal(t, "UNSUPPORTED_CURRENCY", info.GetReason())
+		assert.Equal(t, "SSP", info.GetMetadata()["currency"])
+		assert.Contains(t, strings.Split(info.GetMetadata()["supported"], ","), "USD")
+	}
+	if assert.NotNil(t, badRequest) && assert.Len(t, badRequest.GetFieldViolations(), 1) {
+		assert.Equal(t, "currency_to", badRequest.GetFieldViolations()[0].GetField())
+	}
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+	statusCode, body := impl.postREST("/v1/convert", `{"currency_from": "SSP", "currency_to": "USD", "amount_from": 10}`)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	assert.Contains(t, body, `"reason":"UNSUPPORTED_CURRENCY"`)
+	assert.Contains(t, body, `"field":"currency_from"`)
+}
+
+func (impl *componentTestSuite) TestRatesUnavailable() {
+	t := impl.T()
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(nil, fmt.Errorf("server selection timeout"))
+	_, err := impl.deps.GRPCClient.GetRate(impl.deps.Ctx, &currencyconverter.GetRateRequest{
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "USD",
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(nil, nil)
+	statusCode, body := impl.postREST("/v2/convert", `{"currency_from": "EUR", "currency_to": "USD", "amount_from": "10"}`)
+	assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+	assert.Contains(t, body, `"reason":"NO_RATES"`)
+
+	impl.deps.MockMongoClient.EXPECT().GetRateDocumentAt(gomock.Any(), gomock.Any()).Return(nil, nil)
+	_, err = impl.deps.GRPCClient.Convert(impl.deps.Ctx, &currencyconverter.ConvertRequest{
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "USD",
+		AmountFrom:   10,
+		AsOf:         timestamppb.New(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+// postREST calls the REST gateway directly, the REST test client hides the error body
+func (impl *componentTestSuite) postREST(path, body string) (statusCode int, responseBody string) {
+	endpoint := "http://" + net.JoinHostPort("localhost", impl.deps.Config.Get(confkeys.ExternalRESTPort).String()) + path
+	response, err := http.Post(endpoint, "application/json", strings.NewReader(body))
+	if !assert.NoError(impl.T(), err) {
+		return
+	}
+	defer response.Body.Close()
+	content, err := ioutil.ReadAll(response.Body)
+	assert.NoError(impl.T(), err)
+	return response.StatusCode, strings.ReplaceAll(string(content), " ", "")
+}
+
+func (impl *componentTestSuite) TestConvertV2Pricing() {
+	t := impl.T()
+
+	document := &model.ExchangeRateDocument{
+		Base:  "EUR",
+		Rates: map[string]float32{"EUR": 1, "USD": 1.21009},
+		DecimalRates: map[string]model.Decimal{
+			"EUR": model.NewDecimal(decimal.RequireFromString("1")),
+			"USD": model.NewDecimal(decimal.RequireFromString("1.21009")),
+		},
+	}
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(document, nil)
+	// test_client rule in config_test.yml: 50 bps sell spread, 1% markup and a fixed fee of 2
+	response, err := impl.deps.ServiceV2Client.Convert(impl.deps.Ctx, &currencyconverterv2.ConvertRequest{
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "USD",
+		AmountFrom:   "100",
+		RoundingMode: currencyconverterv2.RoundingMode_ROUNDING_MODE_HALF_UP,
+		ClientId:     "test_client",
+	})
+	if assert.NoError(t, err, "failed to retrieve convert response") {
+		assert.Equal(t, "117.2", response.GetAmount())
+		assert.Equal(t, "121.009", response.GetPricing().GetMidMarketAmount())
+		assert.Equal(t, "0.605045", response.GetPricing().GetSpreadAmount())
+		assert.Equal(t, "1.20403955", response.GetPricing().GetMarkupAmount())
+		assert.Equal(t, "2", response.GetPricing().GetFeeAmount())
+	}
+}
+
+// TestConvertV2FeeExceedsAmount expects the fee to be cut down to what's left of the amount, the breakdown adds up
+func (impl *componentTestSuite) TestConvertV2FeeExceedsAmount() {
+	t := impl.T()
+
+	document := &model.ExchangeRateDocument{
+		Base:  "EUR",
+		Rates: map[string]float32{"EUR": 1, "USD": 1.21009},
+		DecimalRates: map[string]model.Decimal{
+			"EUR": model.NewDecimal(decimal.RequireFromString("1")),
+			"USD": model.NewDecimal(decimal.RequireFromString("1.21009")),
+		},
+	}
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(document, nil)
+	response, err := impl.deps.ServiceV2Client.Convert(impl.deps.Ctx, &currencyconverterv2.ConvertRequest{
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "USD",
+		AmountFrom:   "1",
+		RoundingMode: currencyconverterv2.RoundingMode_ROUNDING_MODE_NONE,
+		ClientId:     "test_client",
+	})
+	if assert.NoError(t, err, "failed to retrieve convert response") {
+		assert.Equal(t, "0", response.GetAmount())
+		assert.Equal(t, "1.21009", response.GetPricing().GetMidMarketAmount())
+		assert.Equal(t, "0.00605045", response.GetPricing().GetSpreadAmount())
+		assert.Equal(t, "0.0120403955", response.GetPricing().GetMarkupAmount())
+		assert.Equal(t, "1.1919991545", response.GetPricing().GetFeeAmount())
+	}
+}
+
+func (impl *componentTestSuite) TestConvertWithoutPricingRule() {
+	t := impl.T()
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+	response, err := impl.deps.ServiceClient.Convert(impl.deps.Ctx, &currencyconverter.ConvertRequest{
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "USD",
+		AmountFrom:   100,
+		ClientId:     "unknown_client",
+	})
+	if assert.NoError(t, err, "failed to retrieve convert response") {
+		expected, _ := impl.calculateExpectedAmount("EUR", "USD", 100)
+		assert.Equal(t, expected, response.GetAmount())
+		assert.Nil(t, response.GetPricing())
+	}
+}
+
+func (impl *componentTestSuite) TestGetRateHistory() {
+	t := impl.T()
+
+	start := time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC)
+	end := start.Add(7 * 24 * time.Hour)
+	points := []model.RateHistoryPoint{
+		{Start: start, Open: 1.2, High: 1.25, Low: 1.19, Close: 1.21, Samples: 24},
+		{Start: start.Add(24 * time.Hour), Open: 1.21, High: 1.22, Low: 1.2, Close: 1.215, Samples: 24},
+	}
+	impl.deps.MockMongoClient.EXPECT().GetRateHistory(gomock.Any(), "EUR", "USD", start, end, model.IntervalDaily).Return(points, nil)
+	response, err := impl.deps.ServiceClient.GetRateHistory(impl.deps.Ctx, &currencyconverter.GetRateHistoryRequest{
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "USD",
+		Start:        timestamppb.New(start),
+		End:          timestamppb.New(end),
+		Interval:     currencyconverter.HistoryInterval_HISTORY_INTERVAL_DAILY,
+	})
+	if assert.NoError(t, err, "failed to retrieve rate history") && assert.Len(t, response.GetPoints(), len(points)) {
+		for i, point := range response.GetPoints() {
+			assert.True(t, points[i].Start.Equal(point.GetStart().AsTime()))
+			assert.Equal(t, float32(points[i].Open), point.GetOpen())
+			assert.Equal(t, float32(points[i].High), point.GetHigh())
+			assert.Equal(t, float32(points[i].Low), point.GetLow())
+			assert.Equal(t, float32(points[i].Close), point.GetClose())
+			assert.Equal(t, points[i].Samples, point.GetSamples())
+		}
+	}
+}
+
+func (impl *componentTestSuite) TestGetRateHistoryInvalidRange() {
+	t := impl.T()
+
+	start := time.Date(2021, 5, 10, 0, 0, 0, 0, time.UTC)
+	_, err := impl.deps.GRPCClient.GetRateHistory(impl.deps.Ctx, &currencyconverter.GetRateHistoryRequest{
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "USD",
+		Start:        timestamppb.New(start),
+		End:          timestamppb.New(start.Add(-time.Hour)),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func (impl *componentTestSuite) TestConvertWithQuote() {
+	t := impl.T()
+
+	document := &model.ExchangeRateDocument{
+		Base:  "EUR",
+		Rates: map[string]float32{"EUR": 1, "USD": 1.21009},
+		DecimalRates: map[string]model.Decimal{
+			"EUR": model.NewDecimal(decimal.RequireFromString("1")),
+			"USD": model.NewDecimal(decimal.RequireFromString("1.21009")),
+		},
+		CreatedAt: time.Date(2021, 5, 13, 7, 31, 3, 0, time.UTC),
+	}
+	var stored *model.QuoteDocument
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(document, nil)
+	impl.deps.MockMongoClient.EXPECT().AddQuoteDocument(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, quote *model.QuoteDocument) error {
+		stored = quote
+		return nil
+	})
+	quote, err := impl.deps.ServiceClient.CreateQuote(impl.deps.Ctx, &currencyconverter.CreateQuoteRequest{
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "USD",
+	})
+	if !assert.NoError(t, err, "failed to create quote") {
+		return
+	}
+	assert.Equal(t, stored.ID, quote.GetQuoteId())
+	assert.Equal(t, float32(1.21009), quote.GetRate())
+	assert.Equal(t, time.Minute, quote.GetExpiresAt().AsTime().Sub(stored.CreatedAt))
+
+	// the quote is honoured regardless of the latest rates
+	impl.deps.MockMongoClient.EXPECT().GetQuoteDocument(gomock.Any(), quote.GetQuoteId()).Return(stored, nil)
+	response, err := impl.deps.ServiceClient.ConvertWithQuote(impl.deps.Ctx, &currencyconverter.ConvertWithQuoteRequest{
+		QuoteId:      quote.GetQuoteId(),
+		AmountFrom:   100,
+		RoundingMode: currencyconverter.RoundingMode_ROUNDING_MODE_HALF_EVEN,
+	})
+	if assert.NoError(t, err, "failed to convert with quote") {
+		assert.Equal(t, "USD", response.GetCurrency())
+		assert.Equal(t, float32(121.01), response.GetAmount())
+		assert.True(t, document.CreatedAt.Equal(response.GetCorrectnessTime().AsTime()))
+	}
+}
+
+func (impl *componentTestSuite) TestConvertWithExpiredQuote() {
+	t := impl.T()
+
+	expired := &model.QuoteDocument{
+		ID:           "expired",
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "USD",
+		Rate:         model.NewDecimal(decimal.RequireFromString("1.21009")),
+		CreatedAt:    time.Now().Add(-2 * time.Minute),
+		ExpiresAt:    time.Now().Add(-time.Minute),
+	}
+	impl.deps.MockMongoClient.EXPECT().GetQuoteDocument(gomock.Any(), "expired").Return(expired, nil)
+	_, err := impl.deps.GRPCClient.ConvertWithQuote(impl.deps.Ctx, &currencyconverter.ConvertWithQuoteRequest{
+		QuoteId:    "expired",
+		AmountFrom: 100,
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	if info := errorInfoOf(err); assert.NotNil(t, info) {
+		assert.Equal(t, "QUOTE_EXPIRED", info.GetReason())
+		assert.Equal(t, "expired", info.GetMetadata()["quote_id"])
+	}
+
+	impl.deps.MockMongoClient.EXPECT().GetQuoteDocument(gomock.Any(), "missing").Return(nil, nil)
+	_, err = impl.deps.GRPCClient.ConvertWithQuote(impl.deps.Ctx, &currencyconverter.ConvertWithQuoteRequest{
+		QuoteId:    "missing",
+		AmountFrom: 100,
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	if info := errorInfoOf(err); assert.NotNil(t, info) {
+		assert.Equal(t, "QUOTE_NOT_FOUND", info.GetReason())
+		assert.Equal(t, []string{"quote_id"}, violatedFields(err))
+	}
+}
+
+func (impl *componentTestSuite) TestDatabaseFailures() {
+	t := impl.T()
+
+	dbErr := fmt.Errorf("server selection error")
+	impl.deps.MockMongoClient.EXPECT().GetQuoteDocument(gomock.Any(), "quote").Return(nil, dbErr)
+	_, err := impl.deps.GRPCClient.ConvertWithQuote(impl.deps.Ctx, &currencyconverter.ConvertWithQuoteRequest{
+		QuoteId:    "quote",
+		AmountFrom: 100,
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	if info := errorInfoOf(err); assert.NotNil(t, info) {
+		assert.Equal(t, "DATABASE_UNAVAILABLE", info.GetReason())
+	}
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+	impl.deps.MockMongoClient.EXPECT().AddQuoteDocument(gomock.Any(), gomock.Any()).Return(dbErr)
+	_, err = impl.deps.GRPCClient.CreateQuote(impl.deps.Ctx, &currencyconverter.CreateQuoteRequest{
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "USD",
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	impl.deps.MockMongoClient.EXPECT().GetRateHistory(gomock.Any(), "EUR", "USD", gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, dbErr)
+	_, err = impl.deps.GRPCClient.GetRateHistory(impl.deps.Ctx, &currencyconverter.GetRateHistoryRequest{
+		CurrencyFrom: "EUR",
+		CurrencyTo:   "USD",
+		Start:        timestamppb.New(time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)),
+		End:          timestamppb.New(time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)),
+	})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	if info := errorInfoOf(err); assert.NotNil(t, info) {
+		assert.Equal(t, "DATABASE_UNAVAILABLE", info.GetReason())
+	}
+}
+
+func errorInfoOf(err error) *errdetails.ErrorInfo {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	return nil
+}
+
 func (impl *componentTestSuite) happyConvert(t *testing.T) gopter.Prop {
 	return prop.ForAll(
 		func(request *currencyconverter.ConvertRequest) bool {
@@ -140,3 +921,162 @@ func (impl *componentTestSuite) getRate(currency string) (result float32, err er
 	}
 	return
 }
+
+// adminContext attaches a JWT with the given roles claim, signed with the configured secret
+func (impl *componentTestSuite) adminContext(roles ...string) context.Context {
+	secret := impl.deps.Config.Get("exchangerate.admin.tokenSecret").String()
+	claims := fmt.Sprintf(`{"sub":"ops","roles":["%s"]}`, strings.Join(roles, `","`))
+	return impl.tokenContext(signToken("HS256", claims, secret))
+}
+
+func (impl *componentTestSuite) tokenContext(token string) context.Context {
+	return metadata.AppendToOutgoingContext(impl.deps.Ctx, "authorization", fmt.Sprintf("Bearer %s", token))
+}
+
+func signToken(algorithm, claims, secret string) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"alg":"%s","typ":"JWT"}`, algorithm)))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(header + "." + payload))
+	return fmt.Sprintf("%s.%s.%s", header, payload, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)))
+}
+
+func (impl *componentTestSuite) TestRefreshRates() {
+	t := impl.T()
+
+	createdAt := time.Date(2021, 5, 14, 10, 6, 14, 0, time.UTC)
+	impl.deps.MockRefresher.EXPECT().Refresh(gomock.Any(), model.AssetClassCrypto, true).Return(temporal.RefreshResult{
+		WorkflowID: "refresh_update_rates_crypto",
+		RunID:      "run",
+		CreatedAt:  createdAt,
+	}, nil)
+	response, err := impl.deps.AdminClient.RefreshRates(impl.adminContext("reader", "admin"), &currencyconverter.RefreshRatesRequest{
+		AssetClass: "crypto",
+		Wait:       true,
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "refresh_update_rates_crypto", response.GetWorkflowId())
+		assert.Equal(t, "run", response.GetRunId())
+		assert.True(t, createdAt.Equal(response.GetCreatedAt().AsTime()))
+	}
+}
+
+func (impl *componentTestSuite) TestBackfillRates() {
+	t := impl.T()
+
+	start := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
+	// the gaps are looked up per day, from the start of the first day
+	impl.deps.MockRefresher.EXPECT().Backfill(gomock.Any(), model.BackfillRequest{Start: start, End: end, Interval: model.IntervalDaily}).Return(temporal.RefreshResult{
+		WorkflowID: "backfill_update_rates",
+		RunID:      "run",
+	}, nil)
+	response, err := impl.deps.AdminClient.BackfillRates(impl.adminContext("admin"), &currencyconverter.BackfillRatesRequest{
+		Start: timestamppb.New(start.Add(10 * time.Hour)),
+		End:   timestamppb.New(end),
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "backfill_update_rates", response.GetWorkflowId())
+		assert.Equal(t, "run", response.GetRunId())
+	}
+
+	_, err = impl.deps.AdminClient.BackfillRates(impl.adminContext("admin"), &currencyconverter.BackfillRatesRequest{
+		Start: timestamppb.New(end),
+		End:   timestamppb.New(start),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func (impl *componentTestSuite) TestRefreshRatesTemporalErrors() {
+	t := impl.T()
+
+	impl.deps.MockRefresher.EXPECT().Refresh(gomock.Any(), model.AssetClassFiat, false).Return(temporal.RefreshResult{}, serviceerror.NewUnavailable("frontend is down"))
+	_, err := impl.deps.AdminClient.RefreshRates(impl.adminContext("admin"), &currencyconverter.RefreshRatesRequest{})
+	st := status.Convert(err)
+	assert.Equal(t, codes.Unavailable, st.Code())
+	var info *errdetails.ErrorInfo
+	for _, detail := range st.Details() {
+		if detail, ok := detail.(*errdetails.ErrorInfo); ok {
+			info = detail
+		}
+	}
+	if assert.NotNil(t, info) {
+		assert.Equal(t, "WORKFLOW_UNAVAILABLE", info.GetReason())
+	}
+
+	impl.deps.MockRefresher.EXPECT().Refresh(gomock.Any(), model.AssetClassFiat, true).Return(temporal.RefreshResult{}, fmt.Errorf("waiting for the workflow: %w", context.DeadlineExceeded))
+	_, err = impl.deps.AdminClient.RefreshRates(impl.adminContext("admin"), &currencyconverter.RefreshRatesRequest{Wait: true})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+	impl.deps.MockRefresher.EXPECT().Backfill(gomock.Any(), gomock.Any()).Return(temporal.RefreshResult{}, serviceerror.NewWorkflowExecutionAlreadyStarted("already started", "", ""))
+	_, err = impl.deps.AdminClient.BackfillRates(impl.adminContext("admin"), &currencyconverter.BackfillRatesRequest{
+		Start: timestamppb.New(time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)),
+		End:   timestamppb.New(time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)),
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func (impl *componentTestSuite) TestRefreshRatesUnauthorized() {
+	t := impl.T()
+
+	_, err := impl.deps.AdminClient.RefreshRates(impl.deps.Ctx, &currencyconverter.RefreshRatesRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = impl.deps.AdminClient.RefreshRates(impl.adminContext("reader"), &currencyconverter.RefreshRatesRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = impl.deps.AdminClient.RefreshRates(impl.adminContext("admin"), &currencyconverter.RefreshRatesRequest{AssetClass: "stocks"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func (impl *componentTestSuite) TestRefreshRatesForgedToken() {
+	t := impl.T()
+
+	secret := impl.deps.Config.Get("exchangerate.admin.tokenSecret").String()
+	admin := `{"sub":"ops","roles":["admin"]}`
+	// "none" tokens come without a signature
+	unsigned := signToken("none", admin, "")
+	unsigned = unsigned[:strings.LastIndex(unsigned, ".")+1]
+	for name, token := range map[string]string{
+		"unsigned":     unsigned,
+		"wrong secret": signToken("HS256", admin, "not-the-secret"),
+		"other alg":    signToken("HS512", admin, secret),
+		"expired":      signToken("HS256", fmt.Sprintf(`{"sub":"ops","roles":["admin"],"exp":%d}`, time.Now().Add(-time.Minute).Unix()), secret),
+	} {
+		_, err := impl.deps.AdminClient.RefreshRates(impl.tokenContext(token), &currencyconverter.RefreshRatesRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), name)
+	}
+}
+
+func (impl *componentTestSuite) TestSchedules() {
+	t := impl.T()
+
+	ctx := impl.adminContext("admin")
+	impl.deps.MockSchedules.EXPECT().WorkflowID(model.AssetClassCrypto).Return("schedule_update_rates_crypto").AnyTimes()
+	impl.deps.MockSchedules.EXPECT().Pause(gomock.Any(), model.AssetClassCrypto).Return(nil)
+	response, err := impl.deps.AdminClient.PauseSchedule(ctx, &currencyconverter.ScheduleSignalRequest{AssetClass: "crypto"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "schedule_update_rates_crypto", response.GetWorkflowId())
+	}
+
+	nextRunAt := time.Date(2021, 5, 14, 11, 0, 0, 0, time.UTC)
+	impl.deps.MockSchedules.EXPECT().Get(gomock.Any(), model.AssetClassCrypto).Return(temporal.ScheduleState{
+		AssetClass:   model.AssetClassCrypto,
+		CronSchedule: "0 * * * *",
+		NextRunAt:    nextRunAt,
+	}, nil)
+	schedule, err := impl.deps.AdminClient.GetSchedule(ctx, &currencyconverter.GetScheduleRequest{AssetClass: "crypto"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "0 * * * *", schedule.GetCronSchedule())
+		assert.True(t, nextRunAt.Equal(schedule.GetNextRunAt().AsTime()))
+		assert.Nil(t, schedule.GetLastRunAt())
+	}
+
+	_, err = impl.deps.AdminClient.UpdateSchedule(ctx, &currencyconverter.UpdateScheduleRequest{AssetClass: "crypto", CronSchedule: "every minute"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	impl.deps.MockSchedules.EXPECT().Trigger(gomock.Any(), model.AssetClassMetal).Return(serviceerror.NewNotFound("workflow not found"))
+	_, err = impl.deps.AdminClient.TriggerSchedule(ctx, &currencyconverter.ScheduleSignalRequest{AssetClass: "metal"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = impl.deps.AdminClient.ResumeSchedule(impl.adminContext("reader"), &currencyconverter.ScheduleSignalRequest{AssetClass: "crypto"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
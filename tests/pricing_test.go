@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bevgene/go-currency-rate/app/mortar"
+	"github.com/bevgene/go-currency-rate/app/pricing"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx"
+)
+
+// untrustedClientConfig keeps the test_client rule of config_test.yml, with client_id not trusted
+const untrustedClientConfig = `
+exchangerate:
+  pricing:
+    trustClientId: false
+`
+
+const negativeFeeConfig = `
+exchangerate:
+  pricing:
+    pairs:
+      eur_usd:
+        fixedFee: -1
+`
+
+// TestPricerIgnoresUntrustedClient expects the client rules to be ignored unless a gateway is trusted to set client_id
+func TestPricerIgnoresUntrustedClient(t *testing.T) {
+	var pricer pricing.Pricer
+	app := createPricerApp(t, untrustedClientConfig, fx.Populate(&pricer))
+	if !assert.NoError(t, app.Err()) {
+		return
+	}
+	breakdown := pricer.Price(context.Background(), "test_client", "EUR", "USD", decimal.NewFromInt(100))
+	assert.False(t, breakdown.Priced)
+	assert.True(t, decimal.NewFromInt(100).Equal(breakdown.Amount))
+}
+
+func TestPricerNegativeCharge(t *testing.T) {
+	app := createPricerApp(t, negativeFeeConfig, fx.Invoke(func(pricing.Pricer) {}))
+	if assert.Error(t, app.Err()) {
+		assert.Contains(t, app.Err().Error(), "exchangerate.pricing.pairs.eur_usd.fixedFee can't be negative")
+	}
+}
+
+func createPricerApp(t *testing.T, config string, options ...fx.Option) *fx.App {
+	dir, err := ioutil.TempDir("", "pricing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	configFile := filepath.Join(dir, "config_pricing.yml")
+	if err = ioutil.WriteFile(configFile, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return fx.New(append([]fx.Option{
+		fx.Supply(t),
+		mortar.ViperFxOption("../config/config.yml", "../config/config_test.yml", configFile),
+		mortar.LoggerFxOption(),
+		fx.Provide(pricing.CreatePricer),
+	}, options...)...)
+}
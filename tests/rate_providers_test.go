@@ -0,0 +1,256 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/clients"
+	"github.com/bevgene/go-currency-rate/app/mortar"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+type (
+	rateProvidersTestSuiteDeps struct {
+		fx.In
+
+		Providers clients.RateProviders
+	}
+
+	rateProvidersTestSuite struct {
+		suite.Suite
+
+		TestApp *fxtest.App
+		server  *httptest.Server
+		dir     string
+		deps    rateProvidersTestSuiteDeps
+	}
+)
+
+const (
+	fixerBody                  = `{"success":true,"date":"2021-05-13","base":"EUR","timestamp":1620891063,"rates":{"EUR":1,"USD":1.21009}}`
+	fixerQuotaBody             = `{"success":false,"error":{"code":104,"type":"usage_limit_reached","info":"Your monthly API request volume has been reached."}}`
+	openExchangeRatesBody      = `{"disclaimer":"Usage subject to terms","timestamp":1620891063,"base":"USD","rates":{"EUR":0.826385,"USD":1}}`
+	exchangeRateHostBody       = `{"motd":{},"success":true,"base":"EUR","date":"2021-05-13","rates":{"EUR":1,"USD":1.210093}}`
+	coinbaseBody               = `{"data":{"currency":"USD","rates":{"USD":"1.0","EUR":"0.826385","BTC":"0.00001958921"}}}`
+	openExchangeRatesQuotaBody = `{"error":true,"status":429,"message":"access_restricted","description":"Access restricted for repeated over-use (status: 429)."}`
+	invalidKeyBody             = `{"success":false,"error":{"code":101,"type":"invalid_access_key","info":"You have not supplied a valid API Access Key."}}`
+	ecbBody                    = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2021-05-13">
+			<Cube currency="USD" rate="1.2076"/>
+			<Cube currency="JPY" rate="132.35"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+	providersConfigFormat = `
+exchangerate:
+  providers:
+    - name: "fixer"
+      type: "fixer"
+      url: "%[1]s/fixer"
+      apiKey: "key"
+    - name: "quota"
+      type: "fixer"
+      url: "%[1]s/quota"
+    - name: "ecb"
+      type: "ecb"
+      url: "%[1]s/ecb"
+    - name: "openexchangerates"
+      type: "openexchangerates"
+      url: "%[1]s/openexchangerates"
+      apiKey: "key"
+    - name: "exchangeratehost"
+      type: "exchangeratehost"
+      url: "%[1]s/exchangeratehost"
+    - name: "coinbase"
+      type: "coinbase"
+      url: "%[1]s/coinbase?currency=USD"
+    - name: "file"
+      type: "file"
+      path: "%[2]s"
+    - name: "unavailable"
+      type: "openexchangerates"
+      url: "%[1]s/unavailable"
+    - name: "malformed"
+      type: "exchangeratehost"
+      url: "%[1]s/malformed"
+    - name: "invalidkey"
+      type: "exchangeratehost"
+      url: "%[1]s/invalidkey"
+    - name: "openexchangeratesquota"
+      type: "openexchangerates"
+      url: "%[1]s/openexchangeratesquota"
+    - name: "invalidurl"
+      type: "openexchangerates"
+      url: "http://[::1"
+`
+)
+
+func TestRateProviders(t *testing.T) {
+	suite.Run(t, new(rateProvidersTestSuite))
+}
+
+func (impl *rateProvidersTestSuite) SetupTest() {
+	t := impl.T()
+
+	mux := http.NewServeMux()
+	for path, body := range map[string]string{
+		"/fixer":             fixerBody,
+		"/fixer/2021-05-13":  fixerBody,
+		"/quota":             fixerQuotaBody,
+		"/ecb":               ecbBody,
+		"/openexchangerates": openExchangeRatesBody,
+		"/exchangeratehost":  exchangeRateHostBody,
+		"/coinbase":          coinbaseBody,
+		"/invalidkey":        invalidKeyBody,
+		"/malformed":         `{"success":true,"rates":`,
+	} {
+		body := body
+		mux.HandleFunc(path, func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(body))
+		})
+	}
+	mux.HandleFunc("/unavailable", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/openexchangeratesquota", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(openExchangeRatesQuotaBody))
+	})
+	impl.server = httptest.NewServer(mux)
+
+	var err error
+	if impl.dir, err = ioutil.TempDir("", "providers"); err != nil {
+		t.Fatal(err)
+	}
+	ratesFile := filepath.Join(impl.dir, "rates.json")
+	configFile := filepath.Join(impl.dir, "config_providers.yml")
+	if err = ioutil.WriteFile(ratesFile, []byte(fixerBody), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(configFile, []byte(fmt.Sprintf(providersConfigFormat, impl.server.URL, ratesFile)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	impl.TestApp = fxtest.New(
+		t,
+		mortar.ViperFxOption("../config/config.yml", "../config/config_test.yml", configFile),
+		mortar.LoggerFxOption(),
+		mortar.HttpClientFxOptions(),
+		fx.Provide(clients.CreateRateProviders),
+		fx.Populate(&impl.deps),
+	)
+	impl.TestApp.RequireStart()
+}
+
+func (impl *rateProvidersTestSuite) TearDownTest() {
+	if impl.TestApp != nil {
+		impl.TestApp.RequireStop()
+	}
+	impl.server.Close()
+	_ = os.RemoveAll(impl.dir)
+}
+
+func (impl *rateProvidersTestSuite) TestProviders() {
+	t := impl.T()
+
+	testCases := []struct {
+		provider  string
+		base      string
+		usd       string
+		timestamp int64
+	}{
+		{"fixer", "EUR", "1.21009", 1620891063},
+		// 16:00 CEST
+		{"ecb", "EUR", "1.2076", time.Date(2021, 5, 13, 14, 0, 0, 0, time.UTC).Unix()},
+		{"openexchangerates", "USD", "1", 1620891063},
+		{"exchangeratehost", "EUR", "1.210093", 0},
+		{"file", "EUR", "1.21009", 1620891063},
+		{"coinbase", "USD", "1", 0},
+	}
+	// the failing providers are covered by TestProviderError
+	assert.Len(t, impl.deps.Providers.All(), len(testCases)+6)
+	for _, testCase := range testCases {
+		provider, ok := impl.deps.Providers.Get(testCase.provider)
+		if !assert.True(t, ok, testCase.provider) {
+			continue
+		}
+		rates, err := provider.GetRates(context.Background())
+		if assert.NoError(t, err, testCase.provider) {
+			assert.Equal(t, testCase.base, rates.Base, testCase.provider)
+			assert.Equal(t, testCase.usd, rates.Rates["USD"].String(), testCase.provider)
+			assert.Equal(t, "1", rates.Rates[testCase.base].String(), testCase.provider)
+			if testCase.timestamp > 0 {
+				assert.Equal(t, testCase.timestamp, rates.Timestamp, testCase.provider)
+			} else {
+				assert.NotZero(t, rates.Timestamp, testCase.provider)
+			}
+		}
+	}
+}
+
+func (impl *rateProvidersTestSuite) TestHistoricalRates() {
+	t := impl.T()
+
+	provider, ok := impl.deps.Providers.Get("fixer")
+	if !assert.True(t, ok) {
+		return
+	}
+	historicalProvider, ok := provider.(clients.HistoricalRateProvider)
+	if !assert.True(t, ok, "fixer should serve historical rates") {
+		return
+	}
+	rates, err := historicalProvider.GetHistoricalRates(context.Background(), time.Date(2021, 5, 13, 0, 0, 0, 0, time.UTC))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "2021-05-13", rates.Date)
+		assert.Equal(t, "1.21009", rates.Rates["USD"].String())
+	}
+	ecb, _ := impl.deps.Providers.Get("ecb")
+	_, ok = ecb.(clients.HistoricalRateProvider)
+	assert.False(t, ok, "ecb serves the latest rates only")
+}
+
+func (impl *rateProvidersTestSuite) TestProviderError() {
+	t := impl.T()
+
+	testCases := []struct {
+		provider  string
+		errorType clients.ProviderErrorType
+		retryable bool
+		message   string
+	}{
+		{"quota", clients.ErrQuotaExceeded, false, "quota QuotaExceeded: 104 usage_limit_reached: Your monthly API request volume has been reached."},
+		{"invalidkey", clients.ErrInvalidKey, false, "invalidkey InvalidKey: 101 invalid_access_key: You have not supplied a valid API Access Key."},
+		{"unavailable", clients.ErrUpstream, true, "unavailable UpstreamUnavailable: unexpected http status code 503"},
+		{"malformed", clients.ErrMalformedBody, false, ""},
+		{"openexchangeratesquota", clients.ErrQuotaExceeded, false, "openexchangeratesquota QuotaExceeded: unexpected http status code 429"},
+		{"invalidurl", clients.ErrInvalidRequest, false, ""},
+	}
+	for _, testCase := range testCases {
+		provider, ok := impl.deps.Providers.Get(testCase.provider)
+		if !assert.True(t, ok, testCase.provider) {
+			continue
+		}
+		_, err := provider.GetRates(context.Background())
+		providerErr, ok := clients.AsProviderError(err)
+		if assert.True(t, ok, testCase.provider) {
+			assert.Equal(t, testCase.errorType, providerErr.Type, testCase.provider)
+			assert.Equal(t, testCase.retryable, providerErr.Retryable(), testCase.provider)
+			if len(testCase.message) > 0 {
+				assert.EqualError(t, err, testCase.message, testCase.provider)
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mock_clients "github.com/bevgene/go-currency-rate/app/clients/mock"
+	"github.com/bevgene/go-currency-rate/app/data"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/bevgene/go-currency-rate/app/mortar"
+	"github.com/go-masonry/mortar/interfaces/monitor"
+	mock_monitor "github.com/go-masonry/mortar/interfaces/monitor/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+const ratesAgeConfig = `
+exchangerate:
+  ratesAgeInterval: "20ms"
+`
+
+// TestRatesAgeReported expects the age of the latest rates to be reported on every tick without any conversion, and to
+// keep growing while mongo can't be reached
+func TestRatesAgeReported(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rates_age")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "config_rates_age.yml")
+	if err = ioutil.WriteFile(configFile, []byte(ratesAgeConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var mockCtrl *gomock.Controller
+	var mockMongoClient *mock_clients.MockMongoClient
+	var expected *model.ExchangeRateDocument
+	var mockMetrics *mock_monitor.MockMetrics
+	testApp := fxtest.New(
+		t,
+		fx.Supply(t),
+		mortar.ViperFxOption("../config/config.yml", "../config/config_test.yml", configFile),
+		mortar.LoggerFxOption(),
+		fx.Provide(
+			NewMockController,
+			mock_clients.NewMockMongoClient,
+			mock_monitor.NewMockMetrics,
+			func(mock *mock_monitor.MockMetrics) monitor.Metrics { return mock },
+			CreateLazyMongoClient,
+			data.CreateRatesBroadcaster,
+			data.CreateCurrencyRateDao,
+			GetRatesDocument,
+		),
+		// the reporter is started along with the dao
+		fx.Invoke(func(data.CurrencyRateDao) {}),
+		fx.Populate(&mockCtrl, &mockMongoClient, &expected, &mockMetrics),
+	)
+	defer mockCtrl.Finish()
+
+	reported := make(chan float64, 16)
+	gauge := mock_monitor.NewMockTagsAwareGauge(mockCtrl)
+	gauge.EXPECT().Set(gomock.Any()).Do(func(value float64) {
+		select {
+		case reported <- value:
+		default:
+		}
+	}).AnyTimes()
+	mockMetrics.EXPECT().Gauge("rates_age_seconds", gomock.Any()).Return(gauge).AnyTimes()
+	gomock.InOrder(
+		mockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(expected, nil),
+		mockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(nil, context.DeadlineExceeded).AnyTimes(),
+	)
+	testApp.RequireStart()
+	defer testApp.RequireStop()
+
+	var previous float64
+	for i := 0; i < 3; i++ {
+		select {
+		case value := <-reported:
+			assert.GreaterOrEqual(t, value, previous)
+			assert.GreaterOrEqual(t, value, time.Since(expected.CreatedAt).Seconds()-1)
+			previous = value
+		case <-time.After(time.Second):
+			assert.Fail(t, "rates age wasn't reported")
+			return
+		}
+	}
+}
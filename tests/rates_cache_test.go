@@ -0,0 +1,226 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	mock_clients "github.com/bevgene/go-currency-rate/app/clients/mock"
+	"github.com/bevgene/go-currency-rate/app/data"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/bevgene/go-currency-rate/app/mortar"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+type (
+	ratesCacheTestSuiteDeps struct {
+		fx.In
+
+		MockCtrl        *gomock.Controller
+		MockMongoClient *mock_clients.MockMongoClient
+		Dao             data.CurrencyRateDao
+		Broadcaster     data.RatesBroadcaster
+		ExpectedRates   *model.ExchangeRateDocument
+	}
+
+	ratesCacheTestSuite struct {
+		suite.Suite
+
+		TestApp *fxtest.App
+		deps    ratesCacheTestSuiteDeps
+		dir     string
+		// loaded is closed once the cache was first refreshed
+		loaded chan struct{}
+	}
+)
+
+// the cache isn't polled during the tests, it's refreshed on start only
+const ratesCacheConfig = `
+exchangerate:
+  cache:
+    enabled: true
+    refreshInterval: "1h"
+    maxStaleness: "200ms"
+`
+
+func TestRatesCache(t *testing.T) {
+	suite.Run(t, new(ratesCacheTestSuite))
+}
+
+func TestRatesCacheInvalidInterval(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "config_cache.yml")
+	if err = ioutil.WriteFile(configFile, []byte(strings.Replace(ratesCacheConfig, `"1h"`, `"0s"`, 1)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	app := fx.New(
+		fx.Supply(t),
+		mortar.ViperFxOption("../config/config.yml", "../config/config_test.yml", configFile),
+		mortar.LoggerFxOption(),
+		fx.Provide(
+			NewMockController,
+			mock_clients.NewMockMongoClient,
+			CreateLazyMongoClient,
+			data.CreateRatesBroadcaster,
+			data.CreateCurrencyRateDao,
+		),
+		fx.Invoke(func(data.CurrencyRateDao) {}),
+	)
+	if assert.Error(t, app.Err()) {
+		assert.Contains(t, app.Err().Error(), "exchangerate.cache.refreshInterval must be positive")
+	}
+}
+
+func (impl *ratesCacheTestSuite) SetupTest() {
+	var err error
+	if impl.dir, err = ioutil.TempDir("", "cache"); err != nil {
+		impl.T().Fatal(err)
+	}
+	configFile := filepath.Join(impl.dir, "config_cache.yml")
+	if err = ioutil.WriteFile(configFile, []byte(ratesCacheConfig), 0600); err != nil {
+		impl.T().Fatal(err)
+	}
+	impl.TestApp = fxtest.New(
+		impl.T(),
+		fx.Supply(impl.T()),
+		mortar.ViperFxOption("../config/config.yml", "../config/config_test.yml", configFile),
+		mortar.LoggerFxOption(),
+		fx.Provide(
+			NewMockController,
+			mock_clients.NewMockMongoClient,
+			CreateLazyMongoClient,
+			data.CreateRatesBroadcaster,
+			data.CreateCurrencyRateDao,
+			GetRatesDocument,
+		),
+		fx.Populate(&impl.deps),
+	)
+	impl.loaded = make(chan struct{})
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).DoAndReturn(func(context.Context) (*model.ExchangeRateDocument, error) {
+		defer close(impl.loaded)
+		return impl.deps.ExpectedRates, nil
+	})
+	impl.TestApp.RequireStart()
+	select {
+	case <-impl.loaded:
+	case <-time.After(time.Second):
+		impl.T().Fatal("cache wasn't refreshed on start")
+	}
+}
+
+func (impl *ratesCacheTestSuite) TearDownTest() {
+	if impl.deps.MockCtrl != nil {
+		impl.deps.MockCtrl.Finish()
+	}
+	if impl.TestApp != nil {
+		impl.TestApp.RequireStop()
+	}
+	if len(impl.dir) > 0 {
+		_ = os.RemoveAll(impl.dir)
+	}
+}
+
+func (impl *ratesCacheTestSuite) TestServedFromCache() {
+	t := impl.T()
+
+	for i := 0; i < 3; i++ {
+		document, err := impl.deps.Dao.GetRates(context.Background())
+		if assert.NoError(t, err) {
+			assert.Same(t, impl.deps.ExpectedRates, document)
+		}
+	}
+}
+
+func (impl *ratesCacheTestSuite) TestPublishedRatesReplaceCache() {
+	t := impl.T()
+
+	published := *impl.deps.ExpectedRates
+	published.CreatedAt = impl.deps.ExpectedRates.CreatedAt.Add(time.Hour)
+	impl.deps.Broadcaster.Publish(context.Background(), &published)
+
+	assert.Eventually(t, func() bool {
+		document, err := impl.deps.Dao.GetRates(context.Background())
+		return err == nil && document == &published
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (impl *ratesCacheTestSuite) TestStalenessIsBounded() {
+	t := impl.T()
+
+	// mongo is unreachable once the cached rates are too old to be served
+	time.Sleep(300 * time.Millisecond)
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(nil, fmt.Errorf("server selection timeout"))
+	_, err := impl.deps.Dao.GetRates(context.Background())
+	assert.Error(t, err)
+
+	// once it's back the rates are cached again
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+	for i := 0; i < 2; i++ {
+		document, err := impl.deps.Dao.GetRates(context.Background())
+		if assert.NoError(t, err) {
+			assert.Same(t, impl.deps.ExpectedRates, document)
+		}
+	}
+}
+
+// the watcher is the one polling mongo, the cache polling on a short interval would read mongo again and again
+const ratesCacheWatchedConfig = `
+exchangerate:
+  cache:
+    enabled: true
+    refreshInterval: "10ms"
+    maxStaleness: "1h"
+  watch:
+    enabled: true
+    pollInterval: "1h"
+`
+
+// TestRatesCacheNotPolledWhenWatched expects mongo to be read once on start while the watcher is enabled
+func TestRatesCacheNotPolledWhenWatched(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "config_cache.yml")
+	if err = ioutil.WriteFile(configFile, []byte(ratesCacheWatchedConfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var mockCtrl *gomock.Controller
+	var mockMongoClient *mock_clients.MockMongoClient
+	var expected *model.ExchangeRateDocument
+	testApp := fxtest.New(
+		t,
+		fx.Supply(t),
+		mortar.ViperFxOption("../config/config.yml", "../config/config_test.yml", configFile),
+		mortar.LoggerFxOption(),
+		fx.Provide(
+			NewMockController,
+			mock_clients.NewMockMongoClient,
+			CreateLazyMongoClient,
+			data.CreateRatesBroadcaster,
+			data.CreateCurrencyRateDao,
+			GetRatesDocument,
+		),
+		fx.Invoke(func(data.CurrencyRateDao) {}),
+		fx.Populate(&mockCtrl, &mockMongoClient, &expected),
+	)
+	mockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(expected, nil).Times(1)
+	testApp.RequireStart()
+	time.Sleep(100 * time.Millisecond)
+	testApp.RequireStop()
+	mockCtrl.Finish()
+}
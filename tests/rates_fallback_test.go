@@ -0,0 +1,163 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mock_clients "github.com/bevgene/go-currency-rate/app/clients/mock"
+	"github.com/bevgene/go-currency-rate/app/data"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/bevgene/go-currency-rate/app/mortar"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+type (
+	ratesFallbackTestSuiteDeps struct {
+		fx.In
+
+		MockCtrl        *gomock.Controller
+		MockMongoClient *mock_clients.MockMongoClient
+		Dao             data.CurrencyRateDao
+		ExpectedRates   *model.ExchangeRateDocument
+	}
+
+	ratesFallbackTestSuite struct {
+		suite.Suite
+
+		TestApp *fxtest.App
+		deps    ratesFallbackTestSuiteDeps
+		dir     string
+		// snapshotFile is kept across the apps started by a test, as it's kept across restarts
+		snapshotFile string
+	}
+)
+
+const ratesFallbackConfig = `
+exchangerate:
+  fallback:
+    path: %q
+    maxStaleness: "1h"
+`
+
+var errMongoUnavailable = fmt.Errorf("server selection timeout")
+
+func TestRatesFallback(t *testing.T) {
+	suite.Run(t, new(ratesFallbackTestSuite))
+}
+
+func (impl *ratesFallbackTestSuite) SetupTest() {
+	var err error
+	if impl.dir, err = ioutil.TempDir("", "fallback"); err != nil {
+		impl.T().Fatal(err)
+	}
+	impl.snapshotFile = filepath.Join(impl.dir, "snapshot", "rates.json")
+	impl.start()
+}
+
+// start starts the app, a running one is stopped first as if the service restarted
+func (impl *ratesFallbackTestSuite) start() {
+	impl.stop()
+	configFile := filepath.Join(impl.dir, "config_fallback.yml")
+	if err := ioutil.WriteFile(configFile, []byte(fmt.Sprintf(ratesFallbackConfig, impl.snapshotFile)), 0600); err != nil {
+		impl.T().Fatal(err)
+	}
+	impl.TestApp = fxtest.New(
+		impl.T(),
+		fx.Supply(impl.T()),
+		mortar.ViperFxOption("../config/config.yml", "../config/config_test.yml", configFile),
+		mortar.LoggerFxOption(),
+		fx.Provide(
+			NewMockController,
+			mock_clients.NewMockMongoClient,
+			CreateLazyMongoClient,
+			data.CreateRatesBroadcaster,
+			data.CreateCurrencyRateDao,
+			GetRatesDocument,
+		),
+		fx.Populate(&impl.deps),
+	)
+	impl.TestApp.RequireStart()
+}
+
+func (impl *ratesFallbackTestSuite) stop() {
+	if impl.deps.MockCtrl != nil {
+		impl.deps.MockCtrl.Finish()
+	}
+	if impl.TestApp != nil {
+		impl.TestApp.RequireStop()
+		impl.TestApp = nil
+	}
+}
+
+func (impl *ratesFallbackTestSuite) TearDownTest() {
+	impl.stop()
+	if len(impl.dir) > 0 {
+		_ = os.RemoveAll(impl.dir)
+	}
+}
+
+func (impl *ratesFallbackTestSuite) TestStaleRatesServed() {
+	t := impl.T()
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+	document, err := impl.deps.Dao.GetRates(context.Background())
+	if assert.NoError(t, err) {
+		assert.False(t, document.Stale())
+	}
+	loadedAt := time.Now()
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(nil, errMongoUnavailable)
+	document, err = impl.deps.Dao.GetRates(context.Background())
+	if assert.NoError(t, err) {
+		assert.True(t, document.Stale())
+		assert.WithinDuration(t, loadedAt, document.SnapshotAt, time.Second)
+		assert.Equal(t, impl.deps.ExpectedRates.Rates, document.Rates)
+		assert.True(t, impl.deps.ExpectedRates.CreatedAt.Equal(document.CreatedAt))
+	}
+}
+
+func (impl *ratesFallbackTestSuite) TestSnapshotKeptAcrossRestarts() {
+	t := impl.T()
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(impl.deps.ExpectedRates, nil)
+	_, err := impl.deps.Dao.GetRates(context.Background())
+	assert.NoError(t, err)
+
+	impl.start()
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(nil, errMongoUnavailable)
+	document, err := impl.deps.Dao.GetRates(context.Background())
+	if assert.NoError(t, err) {
+		assert.True(t, document.Stale())
+		assert.Equal(t, impl.deps.ExpectedRates.Rates, document.Rates)
+		assert.Equal(t, impl.deps.ExpectedRates.Base, document.Base)
+	}
+}
+
+func (impl *ratesFallbackTestSuite) TestStalenessIsBounded() {
+	t := impl.T()
+
+	snapshot := fmt.Sprintf(`{"loaded_at": %q, "document": {"Base": "EUR", "Rates": {"USD": 1.2}}}`, time.Now().Add(-2*time.Hour).Format(time.RFC3339))
+	assert.NoError(t, os.MkdirAll(filepath.Dir(impl.snapshotFile), 0755))
+	assert.NoError(t, ioutil.WriteFile(impl.snapshotFile, []byte(snapshot), 0600))
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(nil, errMongoUnavailable)
+	_, err := impl.deps.Dao.GetRates(context.Background())
+	assert.Equal(t, errMongoUnavailable, err)
+}
+
+func (impl *ratesFallbackTestSuite) TestNoSnapshot() {
+	t := impl.T()
+
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(nil, errMongoUnavailable)
+	_, err := impl.deps.Dao.GetRates(context.Background())
+	assert.Equal(t, errMongoUnavailable, err)
+}
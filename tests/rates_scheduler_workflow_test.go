@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/bevgene/go-currency-rate/app/temporal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/workflow"
+)
+
+type ratesSchedulerWorkflowTestSuite struct {
+	workflowTestSuite
+}
+
+func TestRatesSchedulerWorkflow(t *testing.T) {
+	suite.Run(t, new(ratesSchedulerWorkflowTestSuite))
+}
+
+func (impl *ratesSchedulerWorkflowTestSuite) SetupTest() {
+	impl.setup()
+}
+
+func (impl *ratesSchedulerWorkflowTestSuite) state() (state temporal.ScheduleState) {
+	value, err := impl.env.QueryWorkflow(temporal.ScheduleStateQuery)
+	if assert.NoError(impl.T(), err) {
+		assert.NoError(impl.T(), value.Get(&state))
+	}
+	return
+}
+
+func (impl *ratesSchedulerWorkflowTestSuite) TestSchedule() {
+	t := impl.T()
+
+	start := time.Date(2021, 5, 14, 10, 0, 30, 0, time.UTC)
+	impl.env.SetStartTime(start)
+	runs := 0
+	impl.env.OnWorkflow(impl.deps.Workflow.UpdateRates, mock.Anything, model.AssetClassFiat).Return(func(_ workflow.Context, _ model.AssetClass) (time.Time, error) {
+		runs++
+		return start, nil
+	})
+	impl.env.RegisterDelayedCallback(func() {
+		state := impl.state()
+		// 10:10 and 10:20
+		assert.Equal(t, 2, runs)
+		assert.Equal(t, time.Date(2021, 5, 14, 10, 30, 0, 0, time.UTC), state.NextRunAt.UTC())
+		impl.env.SignalWorkflow(temporal.PauseScheduleSignal, nil)
+	}, 25*time.Minute)
+	impl.env.RegisterDelayedCallback(func() {
+		state := impl.state()
+		assert.Equal(t, 2, runs, "a paused schedule shouldn't run")
+		assert.True(t, state.Paused)
+		assert.True(t, state.NextRunAt.IsZero())
+		impl.env.SignalWorkflow(temporal.UpdateScheduleSignal, "not a spec")
+	}, 50*time.Minute)
+	impl.env.RegisterDelayedCallback(func() {
+		impl.env.SignalWorkflow(temporal.UpdateScheduleSignal, "0 * * * *")
+	}, 51*time.Minute)
+	impl.env.RegisterDelayedCallback(func() {
+		impl.env.SignalWorkflow(temporal.ResumeScheduleSignal, nil)
+	}, 52*time.Minute)
+	impl.env.RegisterDelayedCallback(func() {
+		impl.env.SignalWorkflow(temporal.TriggerScheduleSignal, nil)
+	}, 53*time.Minute)
+	impl.env.RegisterDelayedCallback(func() {
+		state := impl.state()
+		assert.Equal(t, 3, runs)
+		assert.False(t, state.Paused)
+		assert.Equal(t, "0 * * * *", state.CronSchedule)
+		assert.Equal(t, time.Date(2021, 5, 14, 11, 0, 0, 0, time.UTC), state.NextRunAt.UTC())
+		impl.env.CancelWorkflow()
+	}, 55*time.Minute)
+
+	impl.env.ExecuteWorkflow(impl.deps.SchedulerWorkflow.Schedule, temporal.ScheduleState{
+		AssetClass:   model.AssetClassFiat,
+		CronSchedule: "*/10 * * * *",
+	})
+	if assert.True(t, impl.env.IsWorkflowCompleted()) {
+		assert.Error(t, impl.env.GetWorkflowError(), "the schedule runs until it's cancelled")
+	}
+}
@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	mock_clients "github.com/bevgene/go-currency-rate/app/clients/mock"
+	"github.com/bevgene/go-currency-rate/app/data"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/bevgene/go-currency-rate/app/mortar"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+type (
+	ratesWatcherTestSuiteDeps struct {
+		fx.In
+
+		MockCtrl        *gomock.Controller
+		MockMongoClient *mock_clients.MockMongoClient
+		Broadcaster     data.RatesBroadcaster
+		ExpectedRates   *model.ExchangeRateDocument
+	}
+
+	ratesWatcherTestSuite struct {
+		suite.Suite
+
+		TestApp *fxtest.App
+		deps    ratesWatcherTestSuiteDeps
+		dir     string
+
+		// updates subscribed before the app starts, so that the first poll isn't missed
+		updates     <-chan *model.ExchangeRateDocument
+		unsubscribe func()
+
+		lock sync.Mutex
+		// stored is the latest document in mongo, as if it was stored by another instance
+		stored *model.ExchangeRateDocument
+	}
+)
+
+const ratesWatcherConfig = `
+exchangerate:
+  watch:
+    enabled: true
+    pollInterval: "20ms"
+`
+
+func TestRatesWatcher(t *testing.T) {
+	suite.Run(t, new(ratesWatcherTestSuite))
+}
+
+func (impl *ratesWatcherTestSuite) SetupTest() {
+	var err error
+	if impl.dir, err = ioutil.TempDir("", "watcher"); err != nil {
+		impl.T().Fatal(err)
+	}
+	configFile := filepath.Join(impl.dir, "config_watcher.yml")
+	if err = ioutil.WriteFile(configFile, []byte(ratesWatcherConfig), 0600); err != nil {
+		impl.T().Fatal(err)
+	}
+	impl.TestApp = fxtest.New(
+		impl.T(),
+		fx.Supply(impl.T()),
+		mortar.ViperFxOption("../config/config.yml", "../config/config_test.yml", configFile),
+		mortar.LoggerFxOption(),
+		fx.Provide(
+			NewMockController,
+			mock_clients.NewMockMongoClient,
+			CreateLazyMongoClient,
+			data.CreateRatesBroadcaster,
+			data.CreateCurrencyRateDao,
+			GetRatesDocument,
+		),
+		// the watcher is started along with the dao
+		fx.Invoke(func(data.CurrencyRateDao) {}),
+		fx.Populate(&impl.deps),
+	)
+	impl.stored = impl.deps.ExpectedRates
+	impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).DoAndReturn(func(context.Context) (*model.ExchangeRateDocument, error) {
+		impl.lock.Lock()
+		defer impl.lock.Unlock()
+		return impl.stored, nil
+	}).AnyTimes()
+	impl.updates, impl.unsubscribe = impl.deps.Broadcaster.Subscribe()
+	impl.TestApp.RequireStart()
+}
+
+func (impl *ratesWatcherTestSuite) TearDownTest() {
+	if impl.TestApp != nil {
+		impl.TestApp.RequireStop()
+	}
+	if impl.unsubscribe != nil {
+		impl.unsubscribe()
+	}
+	if impl.deps.MockCtrl != nil {
+		impl.deps.MockCtrl.Finish()
+	}
+	if len(impl.dir) > 0 {
+		_ = os.RemoveAll(impl.dir)
+	}
+}
+
+func (impl *ratesWatcherTestSuite) TestStoredRatesPublished() {
+	t := impl.T()
+
+	impl.expectPublished(impl.deps.ExpectedRates)
+
+	stored := *impl.deps.ExpectedRates
+	stored.CreatedAt = impl.deps.ExpectedRates.CreatedAt.Add(time.Hour)
+	impl.lock.Lock()
+	impl.stored = &stored
+	impl.lock.Unlock()
+	impl.expectPublished(&stored)
+
+	// the same rates are polled again and again, subscribers get them once
+	select {
+	case document := <-impl.updates:
+		assert.Fail(t, "rates were published twice", "created at %s", document.CreatedAt)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func (impl *ratesWatcherTestSuite) TestOlderRatesDropped() {
+	t := impl.T()
+
+	impl.expectPublished(impl.deps.ExpectedRates)
+
+	older := *impl.deps.ExpectedRates
+	older.CreatedAt = impl.deps.ExpectedRates.CreatedAt.Add(-time.Hour)
+	impl.deps.Broadcaster.Publish(context.Background(), &older)
+	select {
+	case <-impl.updates:
+		assert.Fail(t, "older rates were published")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func (impl *ratesWatcherTestSuite) expectPublished(expected *model.ExchangeRateDocument) {
+	select {
+	case document := <-impl.updates:
+		assert.Same(impl.T(), expected, document)
+	case <-time.After(time.Second):
+		assert.Fail(impl.T(), "rates weren't published")
+	}
+}
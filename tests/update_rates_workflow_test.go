@@ -0,0 +1,489 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bevgene/go-currency-rate/app/clients"
+	mock_clients "github.com/bevgene/go-currency-rate/app/clients/mock"
+	"github.com/bevgene/go-currency-rate/app/data"
+	"github.com/bevgene/go-currency-rate/app/model"
+	"github.com/bevgene/go-currency-rate/app/mortar"
+	"github.com/bevgene/go-currency-rate/app/temporal"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/testsuite"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+type (
+	updateRatesWorkflowTestSuiteDeps struct {
+		fx.In
+
+		MockCtrl           *gomock.Controller
+		MockExchangeClient *mock_clients.MockExchangeClient
+		MockMongoClient    *mock_clients.MockMongoClient
+		Workflow           *temporal.UpdateRatesWorkflow
+		BackfillWorkflow   *temporal.BackfillRatesWorkflow
+		SchedulerWorkflow  *temporal.RatesSchedulerWorkflow
+		Activities         *temporal.ExchangeActivities
+		Rates              *model.ExchangeRatesModel
+		RatesBroadcaster   data.RatesBroadcaster
+	}
+
+	workflowTestSuite struct {
+		suite.Suite
+		testsuite.WorkflowTestSuite
+
+		TestApp *fxtest.App
+		env     *testsuite.TestWorkflowEnvironment
+		deps    updateRatesWorkflowTestSuiteDeps
+		// dir holds the extra configuration files of the test
+		dir string
+	}
+
+	updateRatesWorkflowTestSuite struct {
+		workflowTestSuite
+	}
+
+	consensusWorkflowTestSuite struct {
+		workflowTestSuite
+	}
+
+	assetClassesWorkflowTestSuite struct {
+		workflowTestSuite
+	}
+)
+
+const consensusConfig = `
+exchangerate:
+  consensus:
+    enabled: true
+    base: "EUR"
+    maxDeviationBps: 100
+    minSources: 2
+`
+
+const assetClassesConfig = `
+exchangerate:
+  assetClasses:
+    crypto:
+      providers: ["coinbase"]
+      collection: "crypto_rates"
+`
+
+func TestUpdateRatesWorkflow(t *testing.T) {
+	suite.Run(t, new(updateRatesWorkflowTestSuite))
+}
+
+func TestConsensusWorkflow(t *testing.T) {
+	suite.Run(t, new(consensusWorkflowTestSuite))
+}
+
+func TestAssetClassesWorkflow(t *testing.T) {
+	suite.Run(t, new(assetClassesWorkflowTestSuite))
+}
+
+func (impl *updateRatesWorkflowTestSuite) SetupTest() {
+	impl.setup()
+}
+
+func (impl *consensusWorkflowTestSuite) SetupTest() {
+	impl.setup(impl.writeConfig(consensusConfig))
+}
+
+func (impl *assetClassesWorkflowTestSuite) SetupTest() {
+	impl.setup(impl.writeConfig(assetClassesConfig))
+}
+
+// writeConfig writes an extra configuration file that's removed once the test is done
+func (impl *workflowTestSuite) writeConfig(content string) string {
+	var err error
+	if impl.dir, err = ioutil.TempDir("", "workflow"); err != nil {
+		impl.T().Fatal(err)
+	}
+	configFile := filepath.Join(impl.dir, "config_workflow.yml")
+	if err = ioutil.WriteFile(configFile, []byte(content), 0600); err != nil {
+		impl.T().Fatal(err)
+	}
+	return configFile
+}
+
+func (impl *workflowTestSuite) setup(extraConfigFiles ...string) {
+	configFiles := append([]string{"../config/config_test.yml"}, extraConfigFiles...)
+	impl.TestApp = fxtest.New(
+		impl.T(),
+		fx.Supply(impl.T()),
+		mortar.ViperFxOption("../config/config.yml", configFiles...),
+		mortar.LoggerFxOption(),
+		fx.Provide(
+			NewMockController,
+			mock_clients.NewMockMongoClient,
+			mock_clients.NewMockExchangeClient,
+			CreateExchangeClientMock,
+			CreateLazyMongoClient,
+			createRatesModel,
+			data.CreateRatesBroadcaster,
+			data.CreateCurrencyRateDao,
+			temporal.CreateActivities,
+			temporal.CreateUpdateRatesWorkflow,
+			temporal.CreateBackfillRatesWorkflow,
+			temporal.CreateRatesSchedulerWorkflow,
+		),
+		fx.Populate(&impl.deps),
+	)
+	impl.TestApp.RequireStart()
+
+	impl.env = impl.NewTestWorkflowEnvironment()
+	impl.env.RegisterWorkflow(impl.deps.Workflow.UpdateRates)
+	impl.env.RegisterActivity(impl.deps.Activities.GetRates)
+	impl.env.RegisterActivity(impl.deps.Activities.ValidateRates)
+	impl.env.RegisterActivity(impl.deps.Activities.ValidateHistoricalRates)
+	impl.env.RegisterActivity(impl.deps.Activities.QuarantineRates)
+	impl.env.RegisterActivity(impl.deps.Activities.UpdateRates)
+	impl.env.RegisterWorkflow(impl.deps.BackfillWorkflow.Backfill)
+	impl.env.RegisterActivity(impl.deps.Activities.GetHistoricalRates)
+	impl.env.RegisterActivity(impl.deps.Activities.FindMissingRates)
+	impl.env.RegisterActivity(impl.deps.Activities.AddHistoricalRates)
+	impl.env.RegisterWorkflow(impl.deps.SchedulerWorkflow.Schedule)
+}
+
+// expectNoStoredRates makes the fetched rates the first ones, so that they're not compared to previous rates
+func (impl *workflowTestSuite) expectNoStoredRates() {
+	impl.deps.MockMongoClient.EXPECT().GetLatestAssetClassRateDocument(gomock.Any(), model.AssetClassFiat).Return(nil, nil)
+}
+
+func (impl *workflowTestSuite) TearDownTest() {
+	if impl.deps.MockCtrl != nil {
+		impl.deps.MockCtrl.Finish()
+	}
+	if impl.TestApp != nil {
+		impl.TestApp.RequireStop()
+	}
+	if len(impl.dir) > 0 {
+		_ = os.RemoveAll(impl.dir)
+	}
+}
+
+func (impl *updateRatesWorkflowTestSuite) TestPrimaryProvider() {
+	t := impl.T()
+
+	impl.deps.MockExchangeClient.EXPECT().Providers().Return([]string{"fixer", "ecb"})
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "fixer").Return(impl.deps.Rates, nil)
+	impl.expectNoStoredRates()
+	var stored *model.ExchangeRateDocument
+	impl.deps.MockMongoClient.EXPECT().AddRateDocument(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, doc *model.ExchangeRateDocument) error {
+		stored = doc
+		return nil
+	})
+
+	impl.env.ExecuteWorkflow(impl.deps.Workflow.UpdateRates, model.AssetClassFiat)
+	if assert.True(t, impl.env.IsWorkflowCompleted()) && assert.NoError(t, impl.env.GetWorkflowError()) {
+		assert.Equal(t, "fixer", stored.Provider)
+		var createdAt time.Time
+		if assert.NoError(t, impl.env.GetWorkflowResult(&createdAt)) {
+			assert.True(t, stored.CreatedAt.Equal(createdAt))
+		}
+	}
+}
+
+func (impl *updateRatesWorkflowTestSuite) TestProviderFailover() {
+	t := impl.T()
+
+	impl.deps.MockExchangeClient.EXPECT().Providers().Return([]string{"fixer", "ecb"})
+	// every provider is retried before falling back to the next one, the test environment allows an extra attempt
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "fixer").Return(nil, fmt.Errorf("usage_limit_reached")).MinTimes(3)
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "ecb").Return(impl.deps.Rates, nil)
+	impl.expectNoStoredRates()
+	var stored *model.ExchangeRateDocument
+	impl.deps.MockMongoClient.EXPECT().AddRateDocument(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, doc *model.ExchangeRateDocument) error {
+		stored = doc
+		return nil
+	})
+
+	impl.env.ExecuteWorkflow(impl.deps.Workflow.UpdateRates, model.AssetClassFiat)
+	if assert.True(t, impl.env.IsWorkflowCompleted()) && assert.NoError(t, impl.env.GetWorkflowError()) {
+		assert.Equal(t, "ecb", stored.Provider)
+		// rebased to the canonical base
+		assert.Equal(t, "USD", stored.Base)
+		assert.Equal(t, impl.deps.Rates.Base, stored.ProviderBase)
+		assert.Equal(t, "1", stored.DecimalRates["USD"].String())
+	}
+}
+
+func (impl *updateRatesWorkflowTestSuite) TestNonRetryableProviderError() {
+	t := impl.T()
+
+	quotaErr := &clients.ProviderError{Provider: "fixer", Type: clients.ErrQuotaExceeded, Message: "104 usage_limit_reached"}
+	impl.deps.MockExchangeClient.EXPECT().Providers().Return([]string{"fixer", "ecb"})
+	// an exhausted quota isn't retried, the next provider is used right away
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "fixer").Return(nil, quotaErr).Times(1)
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "ecb").Return(impl.deps.Rates, nil)
+	impl.expectNoStoredRates()
+	var stored *model.ExchangeRateDocument
+	impl.deps.MockMongoClient.EXPECT().AddRateDocument(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, doc *model.ExchangeRateDocument) error {
+		stored = doc
+		return nil
+	})
+
+	impl.env.ExecuteWorkflow(impl.deps.Workflow.UpdateRates, model.AssetClassFiat)
+	if assert.True(t, impl.env.IsWorkflowCompleted()) && assert.NoError(t, impl.env.GetWorkflowError()) {
+		assert.Equal(t, "ecb", stored.Provider)
+	}
+}
+
+func (impl *updateRatesWorkflowTestSuite) TestAllProvidersFail() {
+	t := impl.T()
+
+	upstreamErr := &clients.ProviderError{Provider: "fixer", Type: clients.ErrUpstream, Message: "unexpected http status code 503"}
+	invalidKeyErr := &clients.ProviderError{Provider: "ecb", Type: clients.ErrInvalidKey, Message: "101 invalid_access_key"}
+	impl.deps.MockExchangeClient.EXPECT().Providers().Return([]string{"fixer", "ecb"})
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "fixer").Return(nil, upstreamErr).MinTimes(3)
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "ecb").Return(nil, invalidKeyErr).Times(1)
+
+	impl.env.ExecuteWorkflow(impl.deps.Workflow.UpdateRates, model.AssetClassFiat)
+	if assert.True(t, impl.env.IsWorkflowCompleted()) {
+		assert.Error(t, impl.env.GetWorkflowError())
+	}
+}
+
+func (impl *updateRatesWorkflowTestSuite) TestNoProviders() {
+	t := impl.T()
+
+	impl.deps.MockExchangeClient.EXPECT().Providers().Return(nil)
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), gomock.Any()).Times(0)
+
+	impl.env.ExecuteWorkflow(impl.deps.Workflow.UpdateRates, model.AssetClassFiat)
+	if assert.True(t, impl.env.IsWorkflowCompleted()) && assert.Error(t, impl.env.GetWorkflowError()) {
+		assert.Contains(t, impl.env.GetWorkflowError().Error(), "no providers configured for asset class fiat")
+	}
+}
+
+func (impl *updateRatesWorkflowTestSuite) TestRatesQuarantined() {
+	t := impl.T()
+
+	previous := model.ConvertExchangeRatesModel(*impl.deps.Rates)
+	previous.CreatedAt = previous.CreatedAt.Add(-time.Hour)
+	// GBP jumped by 20%, more than the configured 10%
+	moved := *impl.deps.Rates
+	moved.Rates = map[string]model.Decimal{
+		"EUR": model.NewDecimal(decimal.RequireFromString("1")),
+		"USD": model.NewDecimal(decimal.RequireFromString("1.21009")),
+		"GBP": model.NewDecimal(decimal.RequireFromString("1.032804")),
+	}
+	impl.deps.MockExchangeClient.EXPECT().Providers().Return([]string{"fixer"})
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "fixer").Return(&moved, nil)
+	impl.deps.MockMongoClient.EXPECT().GetLatestAssetClassRateDocument(gomock.Any(), model.AssetClassFiat).Return(previous, nil)
+	var quarantined *model.QuarantinedRatesDocument
+	impl.deps.MockMongoClient.EXPECT().AddQuarantinedRatesDocument(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, doc *model.QuarantinedRatesDocument) error {
+		quarantined = doc
+		return nil
+	})
+	impl.deps.MockMongoClient.EXPECT().AddRateDocument(gomock.Any(), gomock.Any()).Times(0)
+
+	impl.env.ExecuteWorkflow(impl.deps.Workflow.UpdateRates, model.AssetClassFiat)
+	if assert.True(t, impl.env.IsWorkflowCompleted()) && assert.Error(t, impl.env.GetWorkflowError()) {
+		assert.Equal(t, "fixer", quarantined.Provider)
+		if assert.Len(t, quarantined.Violations, 1) {
+			assert.Contains(t, quarantined.Violations[0], "USD/GBP moved by 20.00%")
+		}
+		assert.False(t, quarantined.QuarantinedAt.IsZero())
+	}
+}
+
+// TestSameRatesNotStoredAgain refetches rates of the same publication, e.g. the daily rates of ecb, the run succeeds
+// without quarantining them
+func (impl *updateRatesWorkflowTestSuite) TestSameRatesNotStoredAgain() {
+	t := impl.T()
+
+	previous := model.ConvertExchangeRatesModel(*impl.deps.Rates)
+	impl.deps.MockExchangeClient.EXPECT().Providers().Return([]string{"fixer"})
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "fixer").Return(impl.deps.Rates, nil)
+	impl.deps.MockMongoClient.EXPECT().GetLatestAssetClassRateDocument(gomock.Any(), model.AssetClassFiat).Return(previous, nil)
+	impl.deps.MockMongoClient.EXPECT().AddQuarantinedRatesDocument(gomock.Any(), gomock.Any()).Times(0)
+	impl.deps.MockMongoClient.EXPECT().AddRateDocument(gomock.Any(), gomock.Any()).Return(errDuplicateKey).Times(1)
+
+	impl.env.ExecuteWorkflow(impl.deps.Workflow.UpdateRates, model.AssetClassFiat)
+	if assert.True(t, impl.env.IsWorkflowCompleted()) && assert.NoError(t, impl.env.GetWorkflowError()) {
+		var createdAt time.Time
+		if assert.NoError(t, impl.env.GetWorkflowResult(&createdAt)) {
+			assert.True(t, previous.CreatedAt.Equal(createdAt))
+		}
+	}
+}
+
+// TestRatesAlreadyStored expects the rates stored by an attempt that timed out not to fail the run
+func (impl *updateRatesWorkflowTestSuite) TestRatesAlreadyStored() {
+	t := impl.T()
+
+	impl.deps.MockExchangeClient.EXPECT().Providers().Return([]string{"fixer"})
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "fixer").Return(impl.deps.Rates, nil)
+	impl.expectNoStoredRates()
+	impl.deps.MockMongoClient.EXPECT().AddRateDocument(gomock.Any(), gomock.Any()).Return(errDuplicateKey).Times(1)
+
+	impl.env.ExecuteWorkflow(impl.deps.Workflow.UpdateRates, model.AssetClassFiat)
+	if assert.True(t, impl.env.IsWorkflowCompleted()) {
+		assert.NoError(t, impl.env.GetWorkflowError())
+	}
+}
+
+func (impl *updateRatesWorkflowTestSuite) TestInvalidRatesQuarantined() {
+	t := impl.T()
+
+	previous := model.ConvertExchangeRatesModel(*impl.deps.Rates)
+	previous.CreatedAt = previous.CreatedAt.Add(time.Hour)
+	invalid := *impl.deps.Rates
+	invalid.Rates = map[string]model.Decimal{
+		"EUR": model.NewDecimal(decimal.RequireFromString("1")),
+		"USD": model.NewDecimal(decimal.RequireFromString("1.21009")),
+		"GBP": model.NewDecimal(decimal.RequireFromString("0")),
+	}
+	impl.deps.MockExchangeClient.EXPECT().Providers().Return([]string{"fixer"})
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "fixer").Return(&invalid, nil)
+	impl.deps.MockMongoClient.EXPECT().GetLatestAssetClassRateDocument(gomock.Any(), model.AssetClassFiat).Return(previous, nil)
+	var quarantined *model.QuarantinedRatesDocument
+	impl.deps.MockMongoClient.EXPECT().AddQuarantinedRatesDocument(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, doc *model.QuarantinedRatesDocument) error {
+		quarantined = doc
+		return nil
+	})
+
+	impl.env.ExecuteWorkflow(impl.deps.Workflow.UpdateRates, model.AssetClassFiat)
+	if assert.True(t, impl.env.IsWorkflowCompleted()) && assert.Error(t, impl.env.GetWorkflowError()) {
+		if assert.Len(t, quarantined.Violations, 2) {
+			assert.Equal(t, "invalid GBP rate 0", quarantined.Violations[0])
+			assert.Contains(t, quarantined.Violations[1], "are older than the latest stored rates")
+		}
+	}
+}
+
+func (impl *consensusWorkflowTestSuite) TestOutlierRejected() {
+	t := impl.T()
+
+	sources := map[string]*model.ExchangeRatesModel{
+		"fixer": {Success: true, Base: "EUR", Timestamp: 1620891063, Rates: map[string]model.Decimal{
+			"EUR": model.NewDecimal(decimal.RequireFromString("1")),
+			"USD": model.NewDecimal(decimal.RequireFromString("1.21")),
+			"ARS": model.NewDecimal(decimal.RequireFromString("113.74")),
+		}},
+		// a misplaced decimal on ARS
+		"ecb": {Success: true, Base: "EUR", Timestamp: 1620918000, Rates: map[string]model.Decimal{
+			"EUR": model.NewDecimal(decimal.RequireFromString("1")),
+			"USD": model.NewDecimal(decimal.RequireFromString("1.2076")),
+			"ARS": model.NewDecimal(decimal.RequireFromString("1137.4")),
+		}},
+		"openexchangerates": {Success: true, Base: "USD", Timestamp: 1620889200, Rates: map[string]model.Decimal{
+			"USD": model.NewDecimal(decimal.RequireFromString("1")),
+			"EUR": model.NewDecimal(decimal.RequireFromString("0.826385")),
+			"ARS": model.NewDecimal(decimal.RequireFromString("93.99")),
+		}},
+	}
+	impl.deps.MockExchangeClient.EXPECT().Providers().Return([]string{"fixer", "ecb", "openexchangerates"})
+	for name, rates := range sources {
+		impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), name).Return(rates, nil)
+	}
+	impl.expectNoStoredRates()
+	var stored *model.ExchangeRateDocument
+	impl.deps.MockMongoClient.EXPECT().AddRateDocument(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, doc *model.ExchangeRateDocument) error {
+		stored = doc
+		return nil
+	})
+
+	impl.env.ExecuteWorkflow(impl.deps.Workflow.UpdateRates, model.AssetClassFiat)
+	if assert.True(t, impl.env.IsWorkflowCompleted()) && assert.NoError(t, impl.env.GetWorkflowError()) {
+		assert.Equal(t, temporal.ConsensusProvider, stored.Provider)
+		assert.Equal(t, "USD", stored.Base)
+		assert.Equal(t, "EUR", stored.ProviderBase)
+		assert.Equal(t, int64(1620918000), stored.CreatedAt.Unix())
+		assert.Equal(t, map[string][]string{"ARS": {"ecb"}}, stored.Rejected)
+		assert.Len(t, stored.Sources, len(sources))
+		assert.Equal(t, "1137.4", stored.Sources["ecb"]["ARS"].String())
+		// the consensus is calculated in EUR and rebased to the canonical USD base
+		assert.Equal(t, "1", stored.DecimalRates["USD"].String())
+		eur, _ := stored.DecimalRates["EUR"].Float64()
+		assert.InDelta(t, 1/1.21, eur, 1e-9)
+		ars, _ := stored.DecimalRates["ARS"].Float64()
+		assert.InDelta(t, 113.74/1.21, ars, 0.01)
+	}
+}
+
+func (impl *consensusWorkflowTestSuite) TestNotEnoughSources() {
+	t := impl.T()
+
+	impl.deps.MockExchangeClient.EXPECT().Providers().Return([]string{"fixer", "ecb"})
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "fixer").Return(impl.deps.Rates, nil)
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "ecb").Return(nil, fmt.Errorf("unavailable")).MinTimes(3)
+
+	impl.env.ExecuteWorkflow(impl.deps.Workflow.UpdateRates, model.AssetClassFiat)
+	if assert.True(t, impl.env.IsWorkflowCompleted()) {
+		assert.Error(t, impl.env.GetWorkflowError())
+	}
+}
+
+func (impl *assetClassesWorkflowTestSuite) TestCryptoRates() {
+	t := impl.T()
+
+	crypto := &model.ExchangeRatesModel{Success: true, Base: "USD", Timestamp: 1620891100, Rates: map[string]model.Decimal{
+		"USD": model.NewDecimal(decimal.RequireFromString("1")),
+		"EUR": model.NewDecimal(decimal.RequireFromString("0.826385")),
+		"BTC": model.NewDecimal(decimal.RequireFromString("0.00001958921")),
+		"ETH": model.NewDecimal(decimal.RequireFromString("0.000260416")),
+	}}
+	fiat := model.ConvertExchangeRatesModel(*impl.deps.Rates)
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "coinbase").Return(crypto, nil)
+	var stored *model.ExchangeRateDocument
+	gomock.InOrder(
+		impl.deps.MockMongoClient.EXPECT().GetLatestAssetClassRateDocument(gomock.Any(), model.AssetClassCrypto).Return(nil, nil),
+		impl.deps.MockMongoClient.EXPECT().AddRateDocument(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, doc *model.ExchangeRateDocument) error {
+			stored = doc
+			return nil
+		}),
+		impl.deps.MockMongoClient.EXPECT().GetLatestRateDocument(gomock.Any()).Return(fiat, nil),
+		impl.deps.MockMongoClient.EXPECT().GetLatestAssetClassRateDocument(gomock.Any(), model.AssetClassCrypto).DoAndReturn(func(context.Context, model.AssetClass) (*model.ExchangeRateDocument, error) {
+			return stored, nil
+		}),
+	)
+	updates, unsubscribe := impl.deps.RatesBroadcaster.Subscribe()
+	defer unsubscribe()
+
+	impl.env.ExecuteWorkflow(impl.deps.Workflow.UpdateRates, model.AssetClassCrypto)
+	if !assert.True(t, impl.env.IsWorkflowCompleted()) || !assert.NoError(t, impl.env.GetWorkflowError()) {
+		return
+	}
+	// only the crypto currencies are stored, the fiat ones come from the fiat providers
+	assert.Equal(t, model.AssetClassCrypto, stored.AssetClass)
+	assert.Equal(t, "USD", stored.Base)
+	assert.Len(t, stored.DecimalRates, 3)
+	assert.Equal(t, "0.00001958921", stored.DecimalRates["BTC"].String())
+	select {
+	case published := <-updates:
+		// merged into the fiat rates through the USD rate, keeping the precision of the crypto rates
+		assert.Equal(t, "EUR", published.Base)
+		assert.Equal(t, "0.0000237047071289", published.DecimalRates["BTC"].String())
+		assert.Equal(t, "0.00031512679744", published.DecimalRates["ETH"].String())
+		assert.Equal(t, "1.21009", published.DecimalRates["USD"].String())
+	default:
+		assert.Fail(t, "merged rates weren't published")
+	}
+}
+
+func (impl *assetClassesWorkflowTestSuite) TestFiatSkipsClassProviders() {
+	t := impl.T()
+
+	quotaErr := &clients.ProviderError{Provider: "fixer", Type: clients.ErrQuotaExceeded, Message: "104 usage_limit_reached"}
+	impl.deps.MockExchangeClient.EXPECT().Providers().Return([]string{"fixer", "coinbase"})
+	// coinbase is a crypto provider, it isn't a fallback of the fiat providers
+	impl.deps.MockExchangeClient.EXPECT().GetRates(gomock.Any(), "fixer").Return(nil, quotaErr)
+
+	impl.env.ExecuteWorkflow(impl.deps.Workflow.UpdateRates, model.AssetClassFiat)
+	if assert.True(t, impl.env.IsWorkflowCompleted()) {
+		assert.Error(t, impl.env.GetWorkflowError())
+	}
+}